@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/humzahkiani/council/internal/apply"
+	"github.com/humzahkiani/council/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	applyYes      bool
+	applyDryRun   bool
+	applyForce    bool
+	applySolution int
+)
+
+// newApplyCmd creates the apply subcommand
+func newApplyCmd() *cobra.Command {
+	applyCmd := &cobra.Command{
+		Use:   "apply <session-id>",
+		Short: "Apply the winning solution to the current repository",
+		Long: `Apply the winning solution of a saved session to the current repository.
+
+If the solution contains unified diffs (fenced as diff or patch, or starting
+with "diff --git" or "---"/"+++"), they are checked and applied with git apply
+from the repository root. Otherwise every code block that names a file, e.g.
+` + "```go internal/cache.go" + ` or a first line of "// file: internal/cache.go",
+is written to that path.
+
+A preview is shown before anything changes. The command refuses to apply a
+patch that doesn't apply cleanly, or to overwrite files with uncommitted
+changes or files git doesn't track unless --force is given.
+
+Examples:
+  council apply 2024-01-16_143022_abc123
+  council apply --dry-run ./my-session.json
+  council apply --solution 2 --yes abc123   # Apply Agent 2's solution, e.g. after a tie`,
+		Args: cobra.ExactArgs(1),
+		RunE: runApply,
+	}
+
+	applyCmd.Flags().BoolVarP(&applyYes, "yes", "y", false, "Apply without asking for confirmation")
+	applyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "Show the preview and conflicts without applying")
+	applyCmd.Flags().BoolVar(&applyForce, "force", false, "Overwrite files that have uncommitted changes or aren't tracked by git")
+	applyCmd.Flags().IntVar(&applySolution, "solution", 0, "Apply this agent's solution instead of the winner")
+
+	return applyCmd
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	path, err := resolveSessionPath(args[0])
+	if err != nil {
+		return err
+	}
+
+	store, err := storage.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	session, err := store.Load(path)
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+
+	agentID := applySolution
+	if agentID == 0 {
+		if session.WinnerID == nil {
+			if session.IsTie {
+				return fmt.Errorf("session ended in a tie between agents %v; choose one with --solution", session.TiedAgents)
+			}
+			return fmt.Errorf("session has no winner; choose a solution with --solution")
+		}
+		agentID = *session.WinnerID
+	}
+
+	var content string
+	found := false
+	for _, sol := range session.Solutions {
+		if sol.AgentID == agentID {
+			content, found = sol.Content, true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("session has no solution from agent %d", agentID)
+	}
+
+	plan, err := apply.NewPlan(content)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Applying %s from %s in %s\n\n", plan.Summary(), session.AgentLabel(agentID), plan.Root)
+	fmt.Fprintln(out, plan.Preview())
+
+	conflicts := plan.Conflicts()
+	if len(conflicts) > 0 {
+		fmt.Fprintln(out, "Conflicts:")
+		for _, c := range conflicts {
+			fmt.Fprintf(out, "  %s\n", strings.ReplaceAll(c, "\n", "\n  "))
+		}
+		fmt.Fprintln(out)
+		// Dirty and untracked files can be overwritten on request; a patch that doesn't apply can't be
+		if plan.Patch != "" || !applyForce {
+			if applyDryRun {
+				exitStatus = exitFailed
+				return nil
+			}
+			return fmt.Errorf("not applied: resolve the conflicts above%s", forceHint(plan))
+		}
+	}
+
+	if applyDryRun {
+		fmt.Fprintln(out, "Dry run: nothing was changed.")
+		return nil
+	}
+
+	if !applyYes {
+		fmt.Fprint(out, "Apply these changes? [y/N] ")
+		answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Fprintln(out, "Aborted.")
+			return nil
+		}
+	}
+
+	if err := plan.Apply(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Applied %s.\n", plan.Summary())
+	return nil
+}
+
+// forceHint suggests --force when it would resolve the conflicts
+func forceHint(plan *apply.Plan) string {
+	if plan.Patch != "" {
+		return ""
+	}
+	return " or pass --force"
+}
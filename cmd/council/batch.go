@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/humzahkiani/council/internal/agent"
+	"github.com/humzahkiani/council/internal/batch"
+	"github.com/humzahkiani/council/internal/config"
+	"github.com/humzahkiani/council/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var parallel int
+
+// newBatchCmd creates the batch subcommand
+func newBatchCmd() *cobra.Command {
+	batchCmd := &cobra.Command{
+		Use:   "batch <tasks.jsonl>",
+		Short: "Run a council for every task in a JSONL file",
+		Long: `Run a council for every task in a JSONL file, several at a time.
+
+Each line is a JSON object with a "task" and optional "id", "agents", "rounds",
+"model", "personas", "voting", "sampling", "context", "images" and
+"token_budget" fields that override the flags for that task. Every session is
+saved to ~/.council/sessions/ and tagged with the batch ID.
+
+Example tasks.jsonl:
+  {"id": "prime", "task": "Write a function to check if a number is prime"}
+  {"id": "api", "task": "Design a REST API for a blog", "agents": 5, "rounds": 2}
+
+Examples:
+  council batch tasks.jsonl
+  council batch --parallel 4 --profile review tasks.jsonl`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: validateBatch,
+		RunE:    runBatch,
+	}
+
+	addCouncilFlags(batchCmd)
+	batchCmd.Flags().IntVarP(&parallel, "parallel", "j", 2, "Number of councils to run at once")
+
+	return batchCmd
+}
+
+// validateBatch checks the flags shared by batch and bench, then builds the base runConfig
+func validateBatch(cmd *cobra.Command, args []string) error {
+	if os.Getenv("ANTHROPIC_API_KEY") == "" {
+		return fmt.Errorf("ANTHROPIC_API_KEY environment variable not set")
+	}
+	if parallel < 1 {
+		return fmt.Errorf("--parallel must be at least 1 (got %d)", parallel)
+	}
+
+	var err error
+	runConfig, runProfile, err = buildConfig(cmd)
+	return err
+}
+
+func runBatch(cmd *cobra.Command, args []string) error {
+	tasks, err := batch.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	// Resolve every task before starting so a bad line doesn't fail the batch halfway
+	jobs := make([]batch.Job, len(tasks))
+	for i, task := range tasks {
+		cfg, err := taskConfig(runConfig, task, runProfile.personas)
+		if err != nil {
+			return fmt.Errorf("task %s: %w", task.ID, err)
+		}
+		jobs[i] = batch.Job{ID: task.ID, Config: cfg}
+	}
+
+	ctx, cancel := interruptContext()
+	defer cancel()
+
+	batchID := batch.NewID()
+	fmt.Fprintf(os.Stderr, "Batch %s: %d task(s), %d at a time\n", batchID, len(jobs), parallel)
+
+	results := batch.Run(ctx, batchID, jobs, parallel, os.Stderr)
+	if err := batch.WriteSummary(os.Stdout, batchID, results); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		if r.Status() == batch.StatusFailed {
+			exitStatus = exitFailed
+			break
+		}
+	}
+	return nil
+}
+
+// interruptContext returns a context that is cancelled on SIGINT or SIGTERM
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		fmt.Fprintln(os.Stderr, "\nInterrupted. Shutting down...")
+		cancel()
+	}()
+
+	return ctx, cancel
+}
+
+// taskConfig applies a batch task's overrides to the base configuration
+func taskConfig(base *types.Config, task batch.Task, customPersonas map[string]types.Persona) (*types.Config, error) {
+	cfg := *base
+	cfg.Task = task.Task
+
+	if task.Agents != nil {
+		cfg.AgentCount = *task.Agents
+	}
+	if task.Rounds != nil {
+		cfg.Rounds = *task.Rounds
+	}
+	if task.Model != nil {
+		cfg.Model = *task.Model
+	}
+	if task.TokenBudget != nil {
+		cfg.TokenBudget = *task.TokenBudget
+	}
+	if err := validateCounts(cfg.AgentCount, cfg.Rounds, cfg.TokenBudget); err != nil {
+		return nil, err
+	}
+	if cfg.Mode == types.ModeSelfConsistency && (task.Rounds != nil || task.Voting != nil) {
+		return nil, fmt.Errorf("rounds and voting cannot be set in %s mode", types.ModeSelfConsistency)
+	}
+	if cfg.Mode == types.ModeTournament {
+		if err := validateTournament(cfg.AgentCount, cfg.GroupSize); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateWeights(cfg.Weights, cfg.AgentCount+cfg.Judges); err != nil {
+		return nil, err
+	}
+	if cfg.Synthesis != nil && cfg.Synthesis.Synthesizer > cfg.AgentCount {
+		return nil, fmt.Errorf("synthesizer %d is not one of the %d agents", cfg.Synthesis.Synthesizer, cfg.AgentCount)
+	}
+
+	if task.Voting != nil {
+		method, err := parseVoting(*task.Voting)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Voting = method
+	}
+	if len(cfg.Rubric) > 0 && cfg.Voting == types.VotingPairwise {
+		return nil, fmt.Errorf("a rubric requires %s voting", types.VotingBorda)
+	}
+	if (len(cfg.Weights) > 0 || cfg.AccuracyWeights || cfg.WeighConfidence) && cfg.Voting == types.VotingPairwise {
+		return nil, fmt.Errorf("vote weights require %s voting", types.VotingBorda)
+	}
+	if cfg.Cluster != nil && cfg.Cluster.Tally && cfg.Voting == types.VotingPairwise {
+		return nil, fmt.Errorf("tallying by cluster requires %s voting", types.VotingBorda)
+	}
+
+	if task.Personas != nil {
+		resolved, err := agent.ResolvePersonas(task.Personas, customPersonas)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Personas = resolved
+	}
+
+	if task.Sampling != nil {
+		cfg.Sampling = config.MergeSampling(base.Sampling, task.Sampling)
+		if err := validateSampling(cfg.Sampling); err != nil {
+			return nil, err
+		}
+	}
+
+	if task.Context != nil {
+		cfg.ContextPaths = task.Context
+	}
+	if task.Images != nil {
+		cfg.ImagePaths = task.Images
+	}
+
+	return &cfg, nil
+}
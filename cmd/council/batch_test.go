@@ -0,0 +1,135 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/humzahkiani/council/internal/batch"
+	"github.com/humzahkiani/council/internal/types"
+)
+
+func TestTaskConfig(t *testing.T) {
+	intp := func(n int) *int { return &n }
+	strp := func(s string) *string { return &s }
+	floatp := func(f float64) *float64 { return &f }
+
+	base := func() *types.Config {
+		return &types.Config{
+			AgentCount: 4,
+			Rounds:     1,
+			Model:      "base-model",
+			Voting:     types.VotingBorda,
+			Mode:       types.ModeCouncil,
+			Sampling: map[types.Phase]types.SamplingParams{
+				types.PhaseGenerate: {Temperature: floatp(0.5), MaxTokens: 2048},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		base    func(*types.Config) // Changes to the base config, if any
+		task    batch.Task
+		check   func(t *testing.T, cfg *types.Config)
+		wantErr string
+	}{
+		{
+			name: "no overrides keep the flags",
+			check: func(t *testing.T, cfg *types.Config) {
+				if cfg.AgentCount != 4 || cfg.Rounds != 1 || cfg.Model != "base-model" || cfg.Task != "task" {
+					t.Errorf("config = %+v, want the base values", cfg)
+				}
+			},
+		},
+		{
+			name: "counts and model overridden",
+			task: batch.Task{Agents: intp(6), Rounds: intp(3), Model: strp("task-model"), TokenBudget: intp(1000)},
+			check: func(t *testing.T, cfg *types.Config) {
+				if cfg.AgentCount != 6 || cfg.Rounds != 3 || cfg.Model != "task-model" || cfg.TokenBudget != 1000 {
+					t.Errorf("config = %+v, want the task's values", cfg)
+				}
+			},
+		},
+		{
+			name: "sampling merged per phase",
+			task: batch.Task{Sampling: map[types.Phase]types.SamplingParams{
+				types.PhaseGenerate: {Temperature: floatp(0.9)},
+				types.PhaseVote:     {Temperature: floatp(0.1)},
+			}},
+			check: func(t *testing.T, cfg *types.Config) {
+				gen, vote := cfg.Sampling[types.PhaseGenerate], cfg.Sampling[types.PhaseVote]
+				if *gen.Temperature != 0.9 || gen.MaxTokens != 2048 || *vote.Temperature != 0.1 {
+					t.Errorf("sampling = %+v, want generate 0.9 with 2048 tokens and vote 0.1", cfg.Sampling)
+				}
+			},
+		},
+		{
+			name: "personas resolved",
+			task: batch.Task{Personas: []string{"skeptic"}},
+			check: func(t *testing.T, cfg *types.Config) {
+				if len(cfg.Personas) != 1 || cfg.Personas[0].Name != "Skeptic" {
+					t.Errorf("personas = %+v, want the skeptic", cfg.Personas)
+				}
+			},
+		},
+		{name: "too few agents", task: batch.Task{Agents: intp(2)}, wantErr: "minimum 3 agents"},
+		{name: "negative budget", task: batch.Task{TokenBudget: intp(-1)}, wantErr: "token budget"},
+		{name: "unknown voting", task: batch.Task{Voting: strp("plurality")}, wantErr: "plurality"},
+		{name: "unknown persona", task: batch.Task{Personas: []string{"pirate"}}, wantErr: `unknown persona "pirate"`},
+		{
+			name:    "invalid sampling",
+			task:    batch.Task{Sampling: map[types.Phase]types.SamplingParams{types.PhaseVote: {Temperature: floatp(2)}}},
+			wantErr: "temperature must be between 0 and 1",
+		},
+		{
+			name:    "rounds in self-consistency mode",
+			base:    func(c *types.Config) { c.Mode = types.ModeSelfConsistency },
+			task:    batch.Task{Rounds: intp(2)},
+			wantErr: "rounds and voting cannot be set",
+		},
+		{
+			name:    "pairwise voting with a rubric",
+			base:    func(c *types.Config) { c.Rubric = []types.Criterion{{Name: "correctness", Weight: 1}} },
+			task:    batch.Task{Voting: strp("pairwise")},
+			wantErr: "a rubric requires borda voting",
+		},
+		{
+			name:    "weights beyond the task's agents",
+			base:    func(c *types.Config) { c.Weights = map[int]float64{4: 2} },
+			task:    batch.Task{Agents: intp(3)},
+			wantErr: "vote weight for voter 4",
+		},
+		{
+			name:    "tournament too small",
+			base:    func(c *types.Config) { c.Mode, c.AgentCount, c.GroupSize = types.ModeTournament, 8, 4 },
+			task:    batch.Task{Agents: intp(4)},
+			wantErr: "tournament mode needs more agents",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base()
+			if tt.base != nil {
+				tt.base(cfg)
+			}
+			task := tt.task
+			task.Task = "task"
+
+			got, err := taskConfig(cfg, task, nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("taskConfig: %v", err)
+			}
+			tt.check(t, got)
+			if cfg.Task != "" || *cfg.Sampling[types.PhaseGenerate].Temperature != 0.5 {
+				t.Errorf("base config was modified: %+v", cfg)
+			}
+		})
+	}
+}
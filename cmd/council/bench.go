@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/humzahkiani/council/internal/batch"
+	"github.com/humzahkiani/council/internal/bench"
+	"github.com/humzahkiani/council/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var checkTimeout time.Duration
+
+// newBenchCmd creates the bench subcommand
+func newBenchCmd() *cobra.Command {
+	benchCmd := &cobra.Command{
+		Use:   "bench <dataset.jsonl>",
+		Short: "Score council winners against reference answers",
+		Long: `Run a council for every item in a JSONL dataset and check the solutions
+against reference answers.
+
+Each line is a batch task (see "council batch --help") plus exactly one checker:
+  "expected"  exact match against the extracted final answer, ignoring case,
+              surrounding markdown and a trailing period
+  "regex"     regular expression matched against the extracted final answer
+  "command"   shell command run with the full solution on stdin and the
+              extracted answer in $COUNCIL_ANSWER; exit code 0 means correct.
+              It runs in an empty directory without your environment, so
+              refer to files beside the dataset via $COUNCIL_DATASET_DIR
+
+The final answer is the last \boxed{...}, else the last "Answer: ..." line,
+else the last non-empty line of a solution.
+
+The report compares the accuracy of the council's winner (a tie counts as
+incorrect) with each agent's own solution and with the majority answer, the
+answer given by more agents than any other. Each saved session records which
+solutions were graded correct, for later runs with --weights accuracy.
+
+Example dataset.jsonl:
+  {"id": "sum", "task": "What is 17 * 23? End with 'Answer: <n>'.", "expected": "391"}
+  {"id": "year", "task": "When did Apollo 11 land?", "regex": "^(July )?1969$"}
+  {"id": "fizz", "task": "Write fizzbuzz in Python", "command": "python3 \"$COUNCIL_DATASET_DIR/check_fizz.py\""}
+
+Examples:
+  council bench dataset.jsonl
+  council bench --parallel 4 --personas security,skeptic,pragmatist dataset.jsonl`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: validateBatch,
+		RunE:    runBench,
+	}
+
+	addCouncilFlags(benchCmd)
+	benchCmd.Flags().IntVarP(&parallel, "parallel", "j", 2, "Number of councils to run at once")
+	benchCmd.Flags().DurationVar(&checkTimeout, "check-timeout", bench.DefaultCheckTimeout, "Time limit for each command checker")
+
+	return benchCmd
+}
+
+func runBench(cmd *cobra.Command, args []string) error {
+	items, err := bench.Load(args[0])
+	if err != nil {
+		return err
+	}
+	datasetDir, err := filepath.Abs(filepath.Dir(args[0]))
+	if err != nil {
+		return fmt.Errorf("failed to resolve dataset directory: %w", err)
+	}
+
+	jobs := make([]batch.Job, len(items))
+	for i, item := range items {
+		cfg, err := taskConfig(runConfig, item.Task, runProfile.personas)
+		if err != nil {
+			return fmt.Errorf("item %s: %w", item.ID, err)
+		}
+		jobs[i] = batch.Job{ID: item.ID, Config: cfg}
+	}
+
+	ctx, cancel := interruptContext()
+	defer cancel()
+
+	batchID := batch.NewID()
+	fmt.Fprintf(os.Stderr, "Bench %s: %d item(s), %d at a time\n", batchID, len(jobs), parallel)
+
+	results := batch.Run(ctx, batchID, jobs, parallel, os.Stderr)
+
+	fmt.Fprintln(os.Stderr, "Checking answers...")
+	report := bench.Score(ctx, bench.Checker{Timeout: checkTimeout, Dir: datasetDir}, items, results)
+	saveGrades(results)
+
+	fmt.Printf("\nBench %s\n\n", batchID)
+	return report.Write(os.Stdout)
+}
+
+// saveGrades re-saves each session with the grades of its solutions, so later
+// runs with --weights accuracy can learn from them
+func saveGrades(results []batch.Result) {
+	store, err := storage.New()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: grades not saved: %v\n", err)
+		return
+	}
+	for _, r := range results {
+		if r.Session == nil || r.Path == "" || len(r.Session.Grades) == 0 {
+			continue
+		}
+		if err := store.SaveTo(r.Session, r.Path); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: grades for %s not saved: %v\n", r.ID, err)
+		}
+	}
+}
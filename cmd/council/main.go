@@ -2,28 +2,90 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/spf13/cobra"
+	"github.com/humzahkiani/council/internal/agent"
+	"github.com/humzahkiani/council/internal/attach"
+	"github.com/humzahkiani/council/internal/config"
 	"github.com/humzahkiani/council/internal/council"
+	"github.com/humzahkiani/council/internal/prompt"
 	"github.com/humzahkiani/council/internal/storage"
 	"github.com/humzahkiani/council/internal/tui"
 	"github.com/humzahkiani/council/internal/types"
+	"github.com/humzahkiani/council/internal/verify"
+	"github.com/spf13/cobra"
 )
 
+// defaultRounds is the --rounds default, which self-consistency mode accepts
+const defaultRounds = 1
+
 var (
-	agentCount int
-	rounds     int
-	save       bool
-	outputPath string
-	verbose    bool
-	model      string
+	agentCount  int
+	rounds      int
+	save        bool
+	outputPath  string
+	verbose     bool
+	model       string
+	personas    []string
+	promptsDir  string
+	sampling    samplingFlags
+	voting      string
+	rubric      string
+	weights     string
+	weighConf   bool
+	moderator   string
+	judgeCount  int
+	judgeModel  string
+	mode        string
+	groupSize   int
+	profileName string
+	format      string
+	compact     bool
+	tokenBudget int
+
+	// runConfig is the effective configuration built by buildConfig, and
+	// runProfile the config file options it was built from
+	runConfig  *types.Config
+	runProfile *profileOptions
+
+	// exitStatus is the process exit code once a command has succeeded
+	exitStatus = exitOK
+
+	taskFile          string
+	imagePaths        []string
+	contextPaths      []string
+	contextMaxFileKB  int64
+	contextMaxTotalKB int64
+
+	verifyCmd      string
+	verifyTimeout  time.Duration
+	verifyMemoryMB int
+	verifyFiles    []string
+	showVerify     bool
+
+	synthesize  bool
+	synthTopK   int
+	synthesizer int
+
+	earlyStop          bool
+	consensusThreshold float64
+
+	cluster          bool
+	clusterThreshold float64
+	tallyByCluster   bool
+
+	human       bool
+	humanWeight float64
 )
 
 func main() {
@@ -36,30 +98,57 @@ among them, and uses ranked-choice voting to determine the best solution.
 Examples:
   council run "Write a function to check if a number is prime"
   council run --agents 5 --rounds 2 "Design a REST API for a blog"
+  council run --personas security,performance,skeptic "Review this design"
+  council batch tasks.jsonl       # Run a council per line of a JSONL file
+  council bench dataset.jsonl     # Score councils against reference answers
   council view                    # List all sessions
-  council view <session-id>       # View a specific session`,
+  council view <session-id>       # View a specific session
+  council apply <session-id>      # Apply the winning solution to this repo`,
 	}
 
 	// Run subcommand
 	runCmd := &cobra.Command{
-		Use:   "run [task]",
+		Use:   "run [task | -]",
 		Short: "Run a council deliberation on a task",
 		Long: `Run a council of AI agents to deliberate on a task.
 
+The task is given as an argument, read from stdin when the argument is "-",
+or read from a file with --task-file.
+
 Examples:
   council run "Write a function to check if a number is prime"
-  council run --agents 5 --rounds 2 --save "Design a REST API for a blog"`,
-		Args:    cobra.ExactArgs(1),
+  council run --agents 5 --rounds 2 --save "Design a REST API for a blog"
+  council run --agents 4 --personas security,performance,pragmatist,skeptic "Implement a rate limiter"
+  council run --temperature generate=1.0,vote=0.2 --max-tokens generate=8192 "Design a cache"
+  council run --context main.go --context ./pkg/ "Find the race condition"
+  council run --image mockup.png "Implement this screen in React"
+  council run --profile review --task-file spec.md
+  council run --synthesize --synthesis-top-k 2 "Design a rate limiter"
+  council run --agents 4 --rounds 3 --moderator on "Choose a database for a ledger"
+  council run --agents 16 --mode tournament --group-size 4 "Design a sharded key-value store"
+  council run --agents 8 --mode self-consistency --temperature generate=1.0 "What is 17 * 23 - 5?"
+  council run --agents 4 --judges 3 --judge-model claude-opus-4-20250514 "Write a JSON schema validator"
+  council run --rounds 4 --early-stop --consensus-threshold 0.8 "Pick a queue for job scheduling"
+  council run --verify-cmd "pytest -q" --verify-file tests/ --show-verification "Implement an LRU cache in solution.py"
+  council run --task-file spec.md
+  council run --format json --compact "Write a slugify function" | jq .winning_content
+  git diff | council run -
+
+Exit codes: 0 success, 1 failure, 2 tie, 3 stopped by --token-budget.`,
+		Args:    cobra.MaximumNArgs(1),
 		PreRunE: validateRun,
 		RunE:    runCouncil,
 	}
 
-	runCmd.Flags().IntVarP(&agentCount, "agents", "a", 3, "Number of agents (minimum 3)")
-	runCmd.Flags().IntVarP(&rounds, "rounds", "r", 1, "Number of discussion rounds")
+	addCouncilFlags(runCmd)
 	runCmd.Flags().BoolVarP(&save, "save", "s", false, "Save session to ~/.council/sessions/")
 	runCmd.Flags().StringVarP(&outputPath, "output", "o", "", "Save session to specific file path")
 	runCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Print detailed output during execution")
-	runCmd.Flags().StringVarP(&model, "model", "m", "claude-sonnet-4-20250514", "Claude model to use")
+	runCmd.Flags().StringVar(&format, "format", formatText, "Output format: text, json or yaml (json/yaml send progress to stderr)")
+	runCmd.Flags().BoolVar(&compact, "compact", false, "With --format json|yaml, emit only the winner, scores and winning content")
+	runCmd.Flags().StringVarP(&taskFile, "task-file", "f", "", "Read the task from a file")
+	runCmd.Flags().BoolVar(&human, "human", false, "Critique and rank the anonymised solutions yourself, voting alongside the agents")
+	runCmd.Flags().Float64Var(&humanWeight, "human-weight", 1, "Weight of your vote relative to an agent's")
 
 	// View subcommand
 	viewCmd := &cobra.Command{
@@ -79,45 +168,563 @@ Examples:
 	}
 
 	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(newBatchCmd())
+	rootCmd.AddCommand(newBenchCmd())
 	rootCmd.AddCommand(viewCmd)
+	rootCmd.AddCommand(newApplyCmd())
 
 	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(exitFailed)
 	}
+	os.Exit(exitStatus)
 }
 
+// addCouncilFlags registers the flags that configure a council, shared by run and batch
+func addCouncilFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVarP(&agentCount, "agents", "a", 3, "Number of agents (minimum 3)")
+	cmd.Flags().IntVarP(&rounds, "rounds", "r", defaultRounds, "Number of discussion rounds")
+	cmd.Flags().StringVarP(&model, "model", "m", "claude-sonnet-4-20250514", "Claude model to use")
+	cmd.Flags().StringVarP(&profileName, "profile", "p", "", "Config profile from ~/.council/config.yaml or .council.yaml")
+	cmd.Flags().StringVar(&voting, "voting", string(types.VotingBorda), "Voting method: borda (ranked choice) or pairwise (head-to-head judgments)")
+	cmd.Flags().StringVar(&weights, "weights", "", "Vote weights by voter ID, e.g. 1=2,3=0.5, or accuracy to weight voters by their graded record in past bench runs")
+	cmd.Flags().BoolVar(&weighConf, "weigh-confidence", false, "Multiply each vote's weight by the confidence the voter states in its ranking")
+	cmd.Flags().StringVar(&rubric, "rubric", "", "Weighted criteria voters score each solution on, e.g. correctness=0.5,clarity=0.2,performance=0.3")
+	cmd.Flags().StringVar(&mode, "mode", string(types.ModeCouncil), "Council structure: council, tournament (groups advance winners to a final), or self-consistency (majority final answer, no discussion or vote)")
+	cmd.Flags().IntVar(&groupSize, "group-size", 4, "Agents per group in tournament mode")
+	cmd.Flags().IntVar(&judgeCount, "judges", 0, "Separate judge agents that critique and vote instead of the solvers (0 = the solvers judge)")
+	cmd.Flags().StringVar(&judgeModel, "judge-model", "", "Claude model for the judges (default: --model)")
+	cmd.Flags().StringVar(&moderator, "moderator", string(types.ModeratorAuto), fmt.Sprintf("Moderate discussion rounds: auto (on for %d+ agents), on or off", types.ModeratorMinAgents))
+	cmd.Flags().IntVar(&tokenBudget, "token-budget", 0, "Stop after the phase in which total tokens reach this budget (0 = unlimited)")
+	cmd.Flags().StringVar(&promptsDir, "prompts", "", "Directory of prompt templates (default ~/.council/prompts/ if present)")
+	cmd.Flags().StringSliceVar(&sampling.temperatures, "temperature", nil, "Sampling temperature, e.g. 0.7 or generate=1.0,vote=0.2")
+	cmd.Flags().StringSliceVar(&sampling.topPs, "top-p", nil, "Nucleus sampling top_p, e.g. 0.9 or generate=0.95")
+	cmd.Flags().StringSliceVar(&sampling.maxTokens, "max-tokens", nil, "Max output tokens, e.g. 8192 or generate=8192,vote=1024 (default 4096)")
+	cmd.Flags().StringArrayVar(&sampling.stops, "stop", nil, "Stop sequence, e.g. \"###\" or vote=\"###\" (repeatable)")
+	cmd.Flags().StringSliceVar(&sampling.thinking, "thinking", nil, "Extended thinking budget in tokens, e.g. 4096 or vote=2048")
+	cmd.Flags().StringArrayVar(&imagePaths, "image", nil, "PNG or JPEG image to send to every agent (repeatable)")
+	cmd.Flags().StringArrayVar(&contextPaths, "context", nil, "File, directory or glob to attach as context (repeatable)")
+	cmd.Flags().Int64Var(&contextMaxFileKB, "context-max-file-kb", attach.DefaultMaxFileBytes/1024, "Maximum size of a single context file in KB")
+	cmd.Flags().Int64Var(&contextMaxTotalKB, "context-max-total-kb", attach.DefaultMaxTotalBytes/1024, "Maximum total size of context files in KB")
+	cmd.Flags().StringVar(&verifyCmd, "verify-cmd", "", "Test command run against each solution's code blocks, e.g. \"pytest -q\"")
+	cmd.Flags().DurationVar(&verifyTimeout, "verify-timeout", verify.DefaultTimeout, "Time limit for the test command")
+	cmd.Flags().IntVar(&verifyMemoryMB, "verify-memory-mb", 0, "Virtual memory limit for the test command in MB (0 = unlimited)")
+	cmd.Flags().StringArrayVar(&verifyFiles, "verify-file", nil, "Test file or directory copied next to the solution's code (repeatable)")
+	cmd.Flags().BoolVar(&showVerify, "show-verification", false, "Show test results to agents during discussion and voting")
+	cmd.Flags().BoolVar(&synthesize, "synthesize", false, "After voting, have one agent merge the top solutions into a final answer")
+	cmd.Flags().IntVar(&synthTopK, "synthesis-top-k", 3, "Number of top-ranked solutions given to the synthesizer")
+	cmd.Flags().IntVar(&synthesizer, "synthesizer", 0, "Agent ID that writes the synthesis (default: the winner)")
+	cmd.Flags().BoolVar(&earlyStop, "early-stop", false, "Take a straw poll after each discussion round and skip the rest on consensus")
+	cmd.Flags().Float64Var(&consensusThreshold, "consensus-threshold", 0.75, "Share of agents ranking the leader first that counts as consensus (0-1]")
+	cmd.Flags().BoolVar(&cluster, "cluster", false, "Group near-duplicate solutions by text similarity after generation")
+	cmd.Flags().Float64Var(&clusterThreshold, "cluster-threshold", 0.8, "Text similarity at which two solutions are clustered (0-1]")
+	cmd.Flags().BoolVar(&tallyByCluster, "tally-by-cluster", false, "Decide the vote over clusters, so near-duplicates don't split points")
+	cmd.Flags().StringSliceVar(&personas, "personas", nil, fmt.Sprintf("Personas to assign to agents round-robin (%s)", strings.Join(agent.PersonaNames(), ", ")))
+}
+
+// validateRun checks the task arguments and output flags, then builds runConfig
 func validateRun(cmd *cobra.Command, args []string) error {
 	if os.Getenv("ANTHROPIC_API_KEY") == "" {
 		return fmt.Errorf("ANTHROPIC_API_KEY environment variable not set")
 	}
 
-	if agentCount < 3 {
-		return fmt.Errorf("minimum 3 agents required (got %d)", agentCount)
+	if len(args) == 0 && taskFile == "" {
+		return fmt.Errorf("a task is required: pass it as an argument, \"-\" for stdin, or --task-file")
+	}
+
+	if len(args) > 0 && taskFile != "" {
+		return fmt.Errorf("cannot use both a task argument and --task-file")
+	}
+
+	if err := validateFormat(format); err != nil {
+		return err
+	}
+
+	if compact && format == formatText {
+		return fmt.Errorf("--compact requires --format json or yaml")
+	}
+
+	var err error
+	runConfig, runProfile, err = buildConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	runConfig.Human, err = parseHuman(cmd, runConfig)
+	return err
+}
+
+// parseHuman builds the human participant config from --human and --human-weight, nil if --human isn't set
+func parseHuman(cmd *cobra.Command, cfg *types.Config) (*types.HumanConfig, error) {
+	if !human {
+		if cmd.Flags().Changed("human-weight") {
+			return nil, fmt.Errorf("--human-weight requires --human")
+		}
+		return nil, nil
+	}
+
+	if humanWeight <= 0 {
+		return nil, fmt.Errorf("--human-weight must be positive (got %g)", humanWeight)
+	}
+	if cfg.Voting == types.VotingPairwise {
+		return nil, fmt.Errorf("--human requires --voting %s", types.VotingBorda)
+	}
+	if cfg.Mode != types.ModeCouncil {
+		return nil, fmt.Errorf("--human cannot be used with --mode %s", cfg.Mode)
+	}
+
+	return &types.HumanConfig{Weight: humanWeight}, nil
+}
+
+// buildConfig merges the config file profile with the council flags and validates the result
+func buildConfig(cmd *cobra.Command) (*types.Config, *profileOptions, error) {
+	profile, err := applyProfile(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := validateCounts(agentCount, rounds, tokenBudget); err != nil {
+		return nil, nil, err
+	}
+
+	votingMethod, err := parseVoting(voting)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	voteWeights, accuracyWeights, err := parseWeights(weights)
+	if err != nil {
+		return nil, nil, err
+	}
+	if weights != "" && votingMethod == types.VotingPairwise {
+		return nil, nil, fmt.Errorf("--weights requires --voting %s", types.VotingBorda)
+	}
+	if weighConf && votingMethod == types.VotingPairwise {
+		return nil, nil, fmt.Errorf("--weigh-confidence requires --voting %s", types.VotingBorda)
+	}
+
+	criteria, err := parseRubric(rubric)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(criteria) > 0 && votingMethod == types.VotingPairwise {
+		return nil, nil, fmt.Errorf("--rubric requires --voting %s", types.VotingBorda)
+	}
+
+	councilMode, err := parseMode(mode)
+	if err != nil {
+		return nil, nil, err
+	}
+	if councilMode == types.ModeTournament {
+		if err := validateTournament(agentCount, groupSize); err != nil {
+			return nil, nil, err
+		}
+	} else if cmd.Flags().Changed("group-size") {
+		return nil, nil, fmt.Errorf("--group-size requires --mode tournament")
+	}
+	if councilMode == types.ModeSelfConsistency {
+		if err := validateSelfConsistency(cmd); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	moderatorMode, err := parseModerator(moderator)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := validateJudges(cmd, councilMode, moderatorMode); err != nil {
+		return nil, nil, err
+	}
+	if err := validateWeights(voteWeights, agentCount+judgeCount); err != nil {
+		return nil, nil, err
+	}
+
+	resolvedPersonas, err := agent.ResolvePersonas(personas, profile.personas)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	flagSampling, err := parseSampling(sampling)
+	if err != nil {
+		return nil, nil, err
+	}
+	samplingParams := config.MergeSampling(profile.sampling, flagSampling)
+	if err := validateSampling(samplingParams); err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := prompt.Load(promptsDir); err != nil {
+		return nil, nil, fmt.Errorf("failed to load prompt templates: %w", err)
+	}
+
+	verifyConfig, err := parseVerify(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	synthesisConfig, err := parseSynthesis(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	consensusConfig, err := parseConsensus(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clusterConfig, err := parseCluster(cmd, votingMethod, councilMode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg := &types.Config{
+		Profile:         profile.name,
+		AgentCount:      agentCount,
+		Judges:          judgeCount,
+		JudgeModel:      judgeModel,
+		Rounds:          rounds,
+		Save:            save,
+		OutputPath:      outputPath,
+		Verbose:         verbose,
+		Model:           model,
+		Voting:          votingMethod,
+		Rubric:          criteria,
+		Weights:         voteWeights,
+		AccuracyWeights: accuracyWeights,
+		Moderator:       moderatorMode,
+		Mode:            councilMode,
+		Personas:        resolvedPersonas,
+		PromptsDir:      promptsDir,
+		Sampling:        samplingParams,
+
+		TokenBudget:          tokenBudget,
+		WeighConfidence:      weighConf,
+		ImagePaths:           imagePaths,
+		ContextPaths:         contextPaths,
+		ContextMaxFileBytes:  contextMaxFileKB * 1024,
+		ContextMaxTotalBytes: contextMaxTotalKB * 1024,
+
+		Verify:    verifyConfig,
+		Synthesis: synthesisConfig,
+		Consensus: consensusConfig,
+		Cluster:   clusterConfig,
+	}
+	if councilMode == types.ModeTournament {
+		cfg.GroupSize = groupSize
+	}
+
+	return cfg, profile, nil
+}
+
+// validateJudges checks --judges and --judge-model. Judges replace the
+// solvers in discussion, so they can't be combined with a tournament's groups
+// or an explicit moderator.
+func validateJudges(cmd *cobra.Command, councilMode types.Mode, moderatorMode types.ModeratorMode) error {
+	if judgeCount < 0 {
+		return fmt.Errorf("--judges must not be negative (got %d)", judgeCount)
+	}
+	if judgeCount == 0 {
+		if cmd.Flags().Changed("judge-model") {
+			return fmt.Errorf("--judge-model requires --judges")
+		}
+		return nil
+	}
+	if councilMode == types.ModeTournament {
+		return fmt.Errorf("--judges cannot be used with --mode %s", types.ModeTournament)
+	}
+	if moderatorMode == types.ModeratorOn {
+		return fmt.Errorf("--judges cannot be used with --moderator %s", types.ModeratorOn)
+	}
+	return nil
+}
+
+// validateSelfConsistency rejects the options for discussion and voting,
+// which self-consistency mode skips. Values from a profile count as set.
+func validateSelfConsistency(cmd *cobra.Command) error {
+	conflicts := []struct {
+		flag string
+		set  bool
+	}{
+		{"rounds", cmd.Flags().Changed("rounds") || rounds != defaultRounds},
+		{"voting", voting != string(types.VotingBorda)},
+		{"judges", judgeCount != 0},
+		{"weights", weights != ""},
+		{"weigh-confidence", weighConf},
+		{"rubric", rubric != ""},
+		{"moderator", moderator == string(types.ModeratorOn)},
+		{"early-stop", earlyStop},
+		{"synthesize", synthesize},
+		{"tally-by-cluster", tallyByCluster},
+	}
+	for _, c := range conflicts {
+		if c.set {
+			return fmt.Errorf("--%s cannot be used with --mode %s", c.flag, types.ModeSelfConsistency)
+		}
+	}
+	return nil
+}
+
+// parseVerify builds the verification config from the --verify-* flags, nil if --verify-cmd isn't set
+func parseVerify(cmd *cobra.Command) (*types.VerifyConfig, error) {
+	if verifyCmd == "" {
+		for _, name := range []string{"verify-timeout", "verify-memory-mb", "verify-file", "show-verification"} {
+			if cmd.Flags().Changed(name) {
+				return nil, fmt.Errorf("--%s requires --verify-cmd", name)
+			}
+		}
+		return nil, nil
+	}
+
+	if verifyTimeout <= 0 {
+		return nil, fmt.Errorf("--verify-timeout must be positive (got %s)", verifyTimeout)
+	}
+	if verifyMemoryMB < 0 {
+		return nil, fmt.Errorf("--verify-memory-mb must not be negative (got %d)", verifyMemoryMB)
+	}
+	for _, path := range verifyFiles {
+		if _, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("invalid --verify-file: %w", err)
+		}
+	}
+
+	return &types.VerifyConfig{
+		Command:       verifyCmd,
+		Timeout:       verifyTimeout,
+		MemoryLimitMB: verifyMemoryMB,
+		Files:         verifyFiles,
+		Show:          showVerify,
+	}, nil
+}
+
+// parseSynthesis builds the synthesis config from the --synthes* flags, nil if --synthesize isn't set
+func parseSynthesis(cmd *cobra.Command) (*types.SynthesisConfig, error) {
+	if !synthesize {
+		for _, name := range []string{"synthesis-top-k", "synthesizer"} {
+			if cmd.Flags().Changed(name) {
+				return nil, fmt.Errorf("--%s requires --synthesize", name)
+			}
+		}
+		return nil, nil
+	}
+
+	if synthTopK < 1 {
+		return nil, fmt.Errorf("--synthesis-top-k must be at least 1 (got %d)", synthTopK)
+	}
+	if synthesizer < 0 || synthesizer > agentCount {
+		return nil, fmt.Errorf("--synthesizer must be an agent ID between 1 and %d (got %d)", agentCount, synthesizer)
+	}
+
+	return &types.SynthesisConfig{TopK: synthTopK, Synthesizer: synthesizer}, nil
+}
+
+// parseConsensus builds the early stopping config from --early-stop and --consensus-threshold, nil if disabled
+func parseConsensus(cmd *cobra.Command) (*types.ConsensusConfig, error) {
+	if !earlyStop {
+		if cmd.Flags().Changed("consensus-threshold") {
+			return nil, fmt.Errorf("--consensus-threshold requires --early-stop")
+		}
+		return nil, nil
+	}
+
+	if consensusThreshold <= 0 || consensusThreshold > 1 {
+		return nil, fmt.Errorf("--consensus-threshold must be greater than 0 and at most 1 (got %g)", consensusThreshold)
+	}
+
+	return &types.ConsensusConfig{Threshold: consensusThreshold}, nil
+}
+
+// parseCluster builds the clustering config from --cluster, --cluster-threshold and --tally-by-cluster, nil if --cluster isn't set
+func parseCluster(cmd *cobra.Command, votingMethod types.VotingMethod, councilMode types.Mode) (*types.ClusterConfig, error) {
+	if !cluster {
+		for _, name := range []string{"cluster-threshold", "tally-by-cluster"} {
+			if cmd.Flags().Changed(name) {
+				return nil, fmt.Errorf("--%s requires --cluster", name)
+			}
+		}
+		return nil, nil
+	}
+
+	if clusterThreshold <= 0 || clusterThreshold > 1 {
+		return nil, fmt.Errorf("--cluster-threshold must be greater than 0 and at most 1 (got %g)", clusterThreshold)
+	}
+	if tallyByCluster && votingMethod == types.VotingPairwise {
+		return nil, fmt.Errorf("--tally-by-cluster requires --voting %s", types.VotingBorda)
+	}
+	if tallyByCluster && councilMode == types.ModeTournament {
+		return nil, fmt.Errorf("--tally-by-cluster cannot be used with --mode %s", types.ModeTournament)
+	}
+
+	return &types.ClusterConfig{Threshold: clusterThreshold, Tally: tallyByCluster}, nil
+}
+
+// validateCounts checks the agent count, round count and token budget
+func validateCounts(agents, rounds, budget int) error {
+	if agents < 3 {
+		return fmt.Errorf("minimum 3 agents required (got %d)", agents)
 	}
 
 	if rounds < 1 {
 		return fmt.Errorf("minimum 1 discussion round required (got %d)", rounds)
 	}
 
+	if budget < 0 {
+		return fmt.Errorf("token budget must not be negative (got %d)", budget)
+	}
+
+	return nil
+}
+
+// parseVoting validates a voting method name
+func parseVoting(name string) (types.VotingMethod, error) {
+	var names []string
+	for _, method := range types.VotingMethods {
+		if string(method) == name {
+			return method, nil
+		}
+		names = append(names, string(method))
+	}
+	return "", fmt.Errorf("unknown voting method %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// parseRubric parses "name=weight,..." into rubric criteria with weights
+// normalised to sum to 1, nil for an empty spec
+func parseRubric(spec string) ([]types.Criterion, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+
+	var criteria []types.Criterion
+	seen := make(map[string]bool)
+	total := 0.0
+	for _, part := range strings.Split(spec, ",") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid rubric criterion %q: expected name=weight", part)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate rubric criterion %q", name)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight for rubric criterion %q: %w", name, err)
+		}
+		if weight <= 0 {
+			return nil, fmt.Errorf("weight for rubric criterion %q must be positive (got %g)", name, weight)
+		}
+		seen[name] = true
+		total += weight
+		criteria = append(criteria, types.Criterion{Name: name, Weight: weight})
+	}
+
+	for i := range criteria {
+		criteria[i].Weight /= total
+	}
+	return criteria, nil
+}
+
+// parseWeights parses --weights: "accuracy", or "id=weight,..." giving each listed
+// voter's vote weight. An empty spec leaves every vote with weight 1.
+func parseWeights(spec string) (map[int]float64, bool, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, false, nil
+	}
+	if spec == "accuracy" {
+		return nil, true, nil
+	}
+
+	result := make(map[int]float64)
+	for _, part := range strings.Split(spec, ",") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, false, fmt.Errorf("invalid vote weight %q: expected id=weight", part)
+		}
+		id, err := strconv.Atoi(strings.TrimSpace(key))
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid voter ID in vote weight %q", part)
+		}
+		if _, dup := result[id]; dup {
+			return nil, false, fmt.Errorf("duplicate vote weight for voter %d", id)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid vote weight for voter %d: %w", id, err)
+		}
+		if weight <= 0 {
+			return nil, false, fmt.Errorf("vote weight for voter %d must be positive (got %g)", id, weight)
+		}
+		result[id] = weight
+	}
+	return result, false, nil
+}
+
+// validateWeights checks that every weighted voter is one of the agents or judges
+func validateWeights(weights map[int]float64, voters int) error {
+	for id := range weights {
+		if id < 1 || id > voters {
+			return fmt.Errorf("vote weight for voter %d, but voter IDs run from 1 to %d", id, voters)
+		}
+	}
+	return nil
+}
+
+// parseMode validates a council mode
+func parseMode(name string) (types.Mode, error) {
+	var names []string
+	for _, m := range types.Modes {
+		if string(m) == name {
+			return m, nil
+		}
+		names = append(names, string(m))
+	}
+	return "", fmt.Errorf("unknown mode %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// validateTournament checks that the agents can be split into at least two groups
+func validateTournament(agents, groupSize int) error {
+	if groupSize < types.MinGroupSize {
+		return fmt.Errorf("--group-size must be at least %d (got %d)", types.MinGroupSize, groupSize)
+	}
+	if agents <= groupSize || agents < 2*types.MinGroupSize {
+		return fmt.Errorf("tournament mode needs more agents than --group-size and at least %d agents (got %d agents, group size %d)", 2*types.MinGroupSize, agents, groupSize)
+	}
 	return nil
 }
 
+// parseModerator validates a moderator mode
+func parseModerator(name string) (types.ModeratorMode, error) {
+	var names []string
+	for _, mode := range types.ModeratorModes {
+		if string(mode) == name {
+			return mode, nil
+		}
+		names = append(names, string(mode))
+	}
+	return "", fmt.Errorf("unknown moderator mode %q (available: %s)", name, strings.Join(names, ", "))
+}
+
 func runCouncil(cmd *cobra.Command, args []string) error {
-	config := &types.Config{
-		AgentCount: agentCount,
-		Rounds:     rounds,
-		Save:       save,
-		OutputPath: outputPath,
-		Verbose:    verbose,
-		Model:      model,
-		Task:       args[0],
+	task, err := readTask(cmd, args)
+	if err != nil {
+		return err
 	}
 
-	c, err := council.New(config)
+	runConfig.Task = task
+
+	c, err := council.New(runConfig)
 	if err != nil {
 		return err
 	}
 
+	// Structured output owns stdout, so everything else goes to stderr
+	if format != formatText {
+		c.SetProgress(os.Stderr)
+	}
+	if runConfig.Human != nil {
+		c.SetReviewer(reviewSolutions)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -125,16 +732,68 @@ func runCouncil(cmd *cobra.Command, args []string) error {
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		<-sigChan
-		fmt.Println("\nInterrupted. Shutting down...")
+		fmt.Fprintln(os.Stderr, "\nInterrupted. Shutting down...")
 		cancel()
 	}()
 
-	if err := c.Run(ctx); err != nil {
+	// A budget stop still produces output for the partial session
+	if err := c.Run(ctx); err != nil && !errors.Is(err, council.ErrBudgetExceeded) {
 		return err
 	}
 
-	c.Output()
-	return nil
+	exitStatus = exitStatusFor(c.Session())
+
+	switch {
+	case format == formatText:
+		c.Output()
+		return nil
+	case compact:
+		return writeStructured(os.Stdout, format, newResult(c.Session(), c.SavedPath()))
+	default:
+		return writeStructured(os.Stdout, format, c.Session())
+	}
+}
+
+// readTask returns the task from the argument, stdin ("-") or --task-file
+func readTask(cmd *cobra.Command, args []string) (string, error) {
+	var data []byte
+	var err error
+
+	switch {
+	case taskFile != "":
+		data, err = os.ReadFile(taskFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read task file: %w", err)
+		}
+	case args[0] == "-":
+		data, err = io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return "", fmt.Errorf("failed to read task from stdin: %w", err)
+		}
+	default:
+		data = []byte(args[0])
+	}
+
+	task := strings.TrimSpace(string(data))
+	if task == "" {
+		return "", fmt.Errorf("task is empty")
+	}
+	return task, nil
+}
+
+// reviewSolutions runs the human review TUI on stderr, leaving stdout for the results
+func reviewSolutions(task string, solutions []string) (*council.HumanReview, error) {
+	p := tea.NewProgram(tui.NewReviewModel(task, solutions), tea.WithAltScreen(), tea.WithOutput(os.Stderr))
+	finalModel, err := p.Run()
+	if err != nil {
+		return nil, fmt.Errorf("TUI error: %w", err)
+	}
+
+	review, ok := finalModel.(tui.ReviewModel)
+	if !ok || review.Skipped() {
+		return nil, nil
+	}
+	return &council.HumanReview{Critique: review.Critique(), Ranking: review.Ranking()}, nil
 }
 
 func viewSession(cmd *cobra.Command, args []string) error {
@@ -144,38 +803,50 @@ func viewSession(cmd *cobra.Command, args []string) error {
 	}
 
 	// Load specific session
-	sessionPath := args[0]
+	sessionPath, err := resolveSessionPath(args[0])
+	if err != nil {
+		return err
+	}
 
+	return showSession(sessionPath)
+}
+
+// resolveSessionPath turns a session file path or session ID into a file path.
+// IDs are matched against file names in ~/.council/sessions/, which include
+// the first 6 characters of the session's UUID, so full UUIDs also match.
+func resolveSessionPath(arg string) (string, error) {
 	// Check if it's a file path or a session ID
-	if !strings.HasSuffix(sessionPath, ".json") {
-		// It's a session ID, look in ~/.council/sessions/
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return fmt.Errorf("failed to get home directory: %w", err)
-		}
+	if strings.HasSuffix(arg, ".json") {
+		return arg, nil
+	}
 
-		sessionsDir := filepath.Join(homeDir, ".council", "sessions")
-		files, err := os.ReadDir(sessionsDir)
-		if err != nil {
-			return fmt.Errorf("failed to read sessions directory: %w", err)
-		}
+	// It's a session ID, look in ~/.council/sessions/
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	sessionsDir := filepath.Join(homeDir, ".council", "sessions")
+	files, err := os.ReadDir(sessionsDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read sessions directory: %w", err)
+	}
+
+	candidates := []string{arg}
+	if len(arg) > 6 {
+		candidates = append(candidates, "_"+arg[:6]+".json")
+	}
 
-		// Find matching session
-		found := false
+	// Find matching session
+	for _, candidate := range candidates {
 		for _, file := range files {
-			if strings.Contains(file.Name(), sessionPath) {
-				sessionPath = filepath.Join(sessionsDir, file.Name())
-				found = true
-				break
+			if strings.Contains(file.Name(), candidate) {
+				return filepath.Join(sessionsDir, file.Name()), nil
 			}
 		}
-
-		if !found {
-			return fmt.Errorf("session not found: %s", args[0])
-		}
 	}
 
-	return showSession(sessionPath)
+	return "", fmt.Errorf("session not found: %s", arg)
 }
 
 func showSessionList() error {
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// councilCmd returns a command with the council flags registered, which also
+// resets the flag variables to their defaults
+func councilCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test"}
+	addCouncilFlags(cmd)
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatalf("ParseFlags: %v", err)
+	}
+	return cmd
+}
+
+// withConfig points the config search at a fresh home and working directory,
+// writing yaml as the project-local .council.yaml when it isn't empty
+func withConfig(t *testing.T, yaml string) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if yaml == "" {
+		return
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".council.yaml"), []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateSelfConsistency(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		args    []string
+		wantErr string
+	}{
+		{name: "defaults"},
+		{name: "explicit rounds flag", args: []string{"--rounds", "1"}, wantErr: "--rounds"},
+		{name: "rounds from profile", config: "profiles:\n  default:\n    rounds: 2\n", wantErr: "--rounds"},
+		{name: "default rounds from profile", config: "profiles:\n  default:\n    rounds: 1\n"},
+		{name: "judges from profile", config: "profiles:\n  default:\n    judges: 2\n", wantErr: "--judges"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withConfig(t, tt.config)
+			cmd := councilCmd(t, append([]string{"--mode", "self-consistency"}, tt.args...)...)
+			if _, err := applyProfile(cmd); err != nil {
+				t.Fatalf("applyProfile: %v", err)
+			}
+
+			err := validateSelfConsistency(cmd)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want one naming %s", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/humzahkiani/council/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Exit codes for council run, so scripts can tell outcomes apart
+const (
+	exitOK     = 0
+	exitFailed = 1 // Any error, including invalid flags
+	exitTie    = 2 // Completed, but the vote ended in a tie
+	exitBudget = 3 // Stopped early because the token budget ran out
+)
+
+// Output formats for council run
+const (
+	formatText = "text"
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
+var outputFormats = []string{formatText, formatJSON, formatYAML}
+
+// result is the compact summary emitted by --compact instead of the full session
+type result struct {
+	SessionID      string          `json:"session_id"`
+	Task           string          `json:"task"`
+	WinnerID       *int            `json:"winner_id"`
+	Winner         string          `json:"winner,omitempty"` // Display label, including any persona
+	IsTie          bool            `json:"is_tie"`
+	TiedAgents     []int           `json:"tied_agents,omitempty"`
+	Scores         map[int]int     `json:"scores"`
+	WeightedScores map[int]float64 `json:"weighted_scores,omitempty"` // Points with vote weights applied, when a vote is weighted
+	Strengths      map[int]float64 `json:"strengths,omitempty"`       // Bradley-Terry strengths, with --voting pairwise
+	RubricTotals   map[int]float64 `json:"rubric_totals,omitempty"`   // Weighted rubric scores, with --rubric
+	Abstentions    []int           `json:"abstentions,omitempty"`     // Voters who abstained
+	FailedVotes    []int           `json:"failed_votes,omitempty"`    // Voters whose vote failed
+	Clusters       []types.Cluster `json:"clusters,omitempty"`        // Near-duplicate groups, with --cluster
+	Answers        map[int]string  `json:"answers,omitempty"`         // Extracted final answers, in self-consistency mode
+	WinningContent string          `json:"winning_content,omitempty"`
+	Synthesis      string          `json:"synthesis,omitempty"` // Merged answer, with --synthesize
+	Usage          types.Usage     `json:"usage"`
+	Stop           *types.Stop     `json:"stop,omitempty"`
+	DiscussionEnd  *types.Stop     `json:"discussion_end,omitempty"` // Rounds skipped by the moderator or --early-stop
+	SessionPath    string          `json:"session_path,omitempty"`
+}
+
+// validateFormat checks the --format value
+func validateFormat(format string) error {
+	for _, f := range outputFormats {
+		if f == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown output format %q (available: %s)", format, strings.Join(outputFormats, ", "))
+}
+
+// newResult builds the compact result for a session
+func newResult(session *types.Session, path string) result {
+	r := result{
+		SessionID:      session.ID,
+		Task:           session.Task,
+		WinnerID:       session.WinnerID,
+		IsTie:          session.IsTie,
+		TiedAgents:     session.TiedAgents,
+		Scores:         session.Scores,
+		WeightedScores: session.WeightedScores,
+		Strengths:      session.Strengths,
+		RubricTotals:   session.RubricTotals,
+		Abstentions:    session.Abstentions,
+		FailedVotes:    session.FailedVotes,
+		Clusters:       session.Clusters,
+		Answers:        session.Answers,
+		Usage:          session.Usage,
+		Stop:           session.Stop,
+		DiscussionEnd:  session.DiscussionEnd,
+		SessionPath:    path,
+	}
+	if session.Synthesis != nil {
+		r.Synthesis = session.Synthesis.Content
+	}
+	if session.WinnerID != nil {
+		r.Winner = session.AgentLabel(*session.WinnerID)
+		for _, sol := range session.Solutions {
+			if sol.AgentID == *session.WinnerID {
+				r.WinningContent = sol.Content
+				break
+			}
+		}
+	}
+	return r
+}
+
+// writeStructured writes v to w as indented JSON or as YAML
+func writeStructured(w io.Writer, format string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal output: %w", err)
+	}
+
+	if format == formatJSON {
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+
+	// Round-trip through JSON so YAML keys match the json tags and the session file
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return fmt.Errorf("failed to convert output to YAML: %w", err)
+	}
+	resetStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return fmt.Errorf("failed to marshal output: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to marshal output: %w", err)
+	}
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// resetStyle clears the flow and quoting styles carried over from JSON so the
+// output reads as block-style YAML
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+// exitStatusFor maps a finished session to the run's exit code
+func exitStatusFor(session *types.Session) int {
+	switch {
+	case session.Stop != nil && session.Stop.Reason == types.StopBudget:
+		return exitBudget
+	case session.IsTie:
+		return exitTie
+	default:
+		return exitOK
+	}
+}
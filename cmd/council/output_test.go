@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+func TestResultRoundTrip(t *testing.T) {
+	winner := 2
+	session := &types.Session{
+		ID:        "s1",
+		Task:      "Pick one",
+		Voting:    types.VotingPairwise,
+		Solutions: []types.Solution{{AgentID: 1, Content: "one"}, {AgentID: 2, Content: "two"}, {AgentID: 3, Content: "three"}},
+		Scores:    map[int]int{1: 1, 2: 2, 3: 0},
+		Strengths: map[int]float64{1: 0.3, 2: 0.6, 3: 0.1},
+		WinnerID:  &winner,
+	}
+
+	tests := []struct {
+		format string
+		check  func(t *testing.T, out []byte)
+	}{
+		{formatJSON, func(t *testing.T, out []byte) {
+			var got result
+			if err := json.Unmarshal(out, &got); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			if !reflect.DeepEqual(got.Strengths, session.Strengths) {
+				t.Errorf("strengths = %v, want %v", got.Strengths, session.Strengths)
+			}
+			if !reflect.DeepEqual(got.Scores, session.Scores) || got.WinningContent != "two" {
+				t.Errorf("result = %+v, want the session's scores and winning content", got)
+			}
+		}},
+		{formatYAML, func(t *testing.T, out []byte) {
+			for _, want := range []string{"strengths:", `"2": 0.6`, "winning_content: two"} {
+				if !strings.Contains(string(out), want) {
+					t.Errorf("YAML output missing %q:\n%s", want, out)
+				}
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeStructured(&buf, tt.format, newResult(session, "")); err != nil {
+				t.Fatalf("writeStructured: %v", err)
+			}
+			tt.check(t, buf.Bytes())
+		})
+	}
+}
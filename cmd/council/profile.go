@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/humzahkiani/council/internal/config"
+	"github.com/humzahkiani/council/internal/types"
+	"github.com/spf13/cobra"
+)
+
+// profileOptions holds the config file values that don't map directly onto a flag variable
+type profileOptions struct {
+	name     string
+	personas map[string]types.Persona
+	sampling map[types.Phase]types.SamplingParams
+}
+
+// applyProfile loads the config files and copies the selected profile's values
+// into every flag the user didn't set explicitly, so flags always win.
+func applyProfile(cmd *cobra.Command) (*profileOptions, error) {
+	resolved, err := config.Load(profileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	p := resolved.Profile
+	flags := cmd.Flags()
+	unset := func(name string) bool { return !flags.Changed(name) }
+
+	if p.Agents != nil && unset("agents") {
+		agentCount = *p.Agents
+	}
+	if p.Rounds != nil && unset("rounds") {
+		rounds = *p.Rounds
+	}
+	if p.Model != nil && unset("model") {
+		model = *p.Model
+	}
+	if p.Personas != nil && unset("personas") {
+		personas = p.Personas
+	}
+	if p.Voting != nil && unset("voting") {
+		voting = *p.Voting
+	}
+	if p.Moderator != nil && unset("moderator") {
+		moderator = *p.Moderator
+	}
+	if p.Judges != nil && unset("judges") {
+		judgeCount = *p.Judges
+	}
+	if p.JudgeModel != nil && unset("judge-model") {
+		judgeModel = *p.JudgeModel
+	}
+	if p.Weights != nil && unset("weights") {
+		weights = *p.Weights
+	}
+	if p.Prompts != nil && unset("prompts") {
+		promptsDir = *p.Prompts
+	}
+	if p.Save != nil && unset("save") {
+		save = *p.Save
+	}
+	if p.Verbose != nil && unset("verbose") {
+		verbose = *p.Verbose
+	}
+	if p.Context != nil && unset("context") {
+		contextPaths = p.Context
+	}
+	if p.TokenBudget != nil && unset("token-budget") {
+		tokenBudget = *p.TokenBudget
+	}
+
+	return &profileOptions{
+		name:     resolved.Name,
+		personas: resolved.Personas,
+		sampling: p.Sampling,
+	}, nil
+}
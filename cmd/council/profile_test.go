@@ -0,0 +1,75 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+func TestApplyProfile(t *testing.T) {
+	const config = `
+profiles:
+  default:
+    agents: 5
+    rounds: 2
+    model: profile-model
+    personas: [skeptic]
+    sampling:
+      vote: {temperature: 0.2}
+  other:
+    agents: 7
+`
+
+	tests := []struct {
+		name     string
+		args     []string
+		agents   int
+		rounds   int
+		model    string
+		personas []string
+		profile  string
+	}{
+		{
+			name:   "profile fills unset flags",
+			agents: 5, rounds: 2, model: "profile-model", personas: []string{"skeptic"}, profile: "default",
+		},
+		{
+			name:   "flags win over the profile",
+			args:   []string{"--agents", "4", "--model", "flag-model", "--personas", "security"},
+			agents: 4, rounds: 2, model: "flag-model", personas: []string{"security"}, profile: "default",
+		},
+		{
+			name:   "flag set to its default still wins",
+			args:   []string{"--rounds", "1"},
+			agents: 5, rounds: 1, model: "profile-model", personas: []string{"skeptic"}, profile: "default",
+		},
+		{
+			name:   "named profile",
+			args:   []string{"--profile", "other"},
+			agents: 7, rounds: 1, model: "claude-sonnet-4-20250514", profile: "other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withConfig(t, config)
+			cmd := councilCmd(t, tt.args...)
+
+			opts, err := applyProfile(cmd)
+			if err != nil {
+				t.Fatalf("applyProfile: %v", err)
+			}
+			if agentCount != tt.agents || rounds != tt.rounds || model != tt.model || !reflect.DeepEqual(personas, tt.personas) {
+				t.Errorf("got agents=%d rounds=%d model=%q personas=%v, want %d %d %q %v",
+					agentCount, rounds, model, personas, tt.agents, tt.rounds, tt.model, tt.personas)
+			}
+			if opts.name != tt.profile {
+				t.Errorf("profile = %q, want %q", opts.name, tt.profile)
+			}
+			if tt.profile == "default" && *opts.sampling[types.PhaseVote].Temperature != 0.2 {
+				t.Errorf("sampling = %+v, want the profile's vote temperature", opts.sampling)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+// samplingFlags holds the raw values of the per-phase sampling flags
+type samplingFlags struct {
+	temperatures []string
+	topPs        []string
+	maxTokens    []string
+	stops        []string
+	thinking     []string
+}
+
+// minThinkingBudget is the smallest extended thinking budget the API accepts
+const minThinkingBudget = 1024
+
+// minThinkingTopP is the lowest top_p the API accepts alongside extended thinking
+const minThinkingTopP = 0.95
+
+// parseSampling builds per-phase sampling parameters from the sampling flags.
+// Each entry is either "phase=value" or a bare value that applies to every phase.
+func parseSampling(f samplingFlags) (map[types.Phase]types.SamplingParams, error) {
+	sampling := make(map[types.Phase]types.SamplingParams)
+
+	update := func(flag string, entries []string, apply func(*types.SamplingParams, string) error) error {
+		for _, entry := range entries {
+			phases, value, err := splitPhaseValue(entry)
+			if err != nil {
+				return fmt.Errorf("--%s: %w", flag, err)
+			}
+			for _, phase := range phases {
+				params := sampling[phase]
+				if err := apply(&params, value); err != nil {
+					return fmt.Errorf("--%s %s: %w", flag, entry, err)
+				}
+				sampling[phase] = params
+			}
+		}
+		return nil
+	}
+
+	if err := update("temperature", f.temperatures, func(p *types.SamplingParams, v string) error {
+		t, err := parseUnitFloat(v)
+		p.Temperature = &t
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := update("top-p", f.topPs, func(p *types.SamplingParams, v string) error {
+		t, err := parseUnitFloat(v)
+		p.TopP = &t
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := update("max-tokens", f.maxTokens, func(p *types.SamplingParams, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return fmt.Errorf("must be a positive integer")
+		}
+		p.MaxTokens = n
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := update("stop", f.stops, func(p *types.SamplingParams, v string) error {
+		if v == "" {
+			return fmt.Errorf("stop sequence cannot be empty")
+		}
+		p.StopSequences = append(p.StopSequences, v)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := update("thinking", f.thinking, func(p *types.SamplingParams, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < minThinkingBudget {
+			return fmt.Errorf("must be an integer of at least %d", minThinkingBudget)
+		}
+		p.ThinkingBudget = n
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if len(sampling) == 0 {
+		return nil, nil
+	}
+	return sampling, nil
+}
+
+// validateSampling checks the merged sampling parameters from the config file and flags
+func validateSampling(sampling map[types.Phase]types.SamplingParams) error {
+	for phase, params := range sampling {
+		if !isPhase(phase) {
+			return fmt.Errorf("unknown sampling phase %q", phase)
+		}
+		if params.Temperature != nil && (*params.Temperature < 0 || *params.Temperature > 1) {
+			return fmt.Errorf("%s: temperature must be between 0 and 1", phase)
+		}
+		if params.TopP != nil && (*params.TopP < 0 || *params.TopP > 1) {
+			return fmt.Errorf("%s: top_p must be between 0 and 1", phase)
+		}
+		if params.MaxTokens < 0 {
+			return fmt.Errorf("%s: max_tokens must be positive", phase)
+		}
+		if params.ThinkingBudget == 0 {
+			continue
+		}
+		if params.ThinkingBudget < minThinkingBudget {
+			return fmt.Errorf("%s: thinking budget must be at least %d", phase, minThinkingBudget)
+		}
+		if params.Temperature != nil {
+			return fmt.Errorf("%s: temperature cannot be combined with thinking", phase)
+		}
+		if params.TopP != nil && *params.TopP < minThinkingTopP {
+			return fmt.Errorf("%s: top_p must be at least %g with thinking (got %g)", phase, minThinkingTopP, *params.TopP)
+		}
+		if params.MaxTokens > 0 && params.MaxTokens <= params.ThinkingBudget {
+			return fmt.Errorf("%s: max_tokens (%d) must be greater than the thinking budget (%d)", phase, params.MaxTokens, params.ThinkingBudget)
+		}
+	}
+	return nil
+}
+
+// isPhase reports whether phase is a known phase
+func isPhase(phase types.Phase) bool {
+	for _, p := range types.Phases {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// splitPhaseValue parses "phase=value", or a bare value meaning every phase
+func splitPhaseValue(entry string) ([]types.Phase, string, error) {
+	name, value, found := strings.Cut(entry, "=")
+	if !found {
+		return types.Phases, entry, nil
+	}
+
+	for _, phase := range types.Phases {
+		if string(phase) == strings.TrimSpace(name) {
+			return []types.Phase{phase}, value, nil
+		}
+	}
+
+	var names []string
+	for _, phase := range types.Phases {
+		names = append(names, string(phase))
+	}
+	return nil, "", fmt.Errorf("unknown phase %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// parseUnitFloat parses a float in the range [0, 1]
+func parseUnitFloat(v string) (float64, error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil || f < 0 || f > 1 {
+		return 0, fmt.Errorf("must be a number between 0 and 1")
+	}
+	return f, nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+func TestParseSampling(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	every := func(p types.SamplingParams) map[types.Phase]types.SamplingParams {
+		m := make(map[types.Phase]types.SamplingParams)
+		for _, phase := range types.Phases {
+			m[phase] = p
+		}
+		return m
+	}
+
+	tests := []struct {
+		name    string
+		flags   samplingFlags
+		want    map[types.Phase]types.SamplingParams
+		wantErr string
+	}{
+		{name: "no flags"},
+		{
+			name:  "bare value applies to every phase",
+			flags: samplingFlags{temperatures: []string{"0.7"}},
+			want:  every(types.SamplingParams{Temperature: f(0.7)}),
+		},
+		{
+			name:  "phase value",
+			flags: samplingFlags{temperatures: []string{"vote=0.2"}, maxTokens: []string{"generate=8192"}},
+			want: map[types.Phase]types.SamplingParams{
+				types.PhaseVote:     {Temperature: f(0.2)},
+				types.PhaseGenerate: {MaxTokens: 8192},
+			},
+		},
+		{
+			name:  "phase value after a bare one overrides it",
+			flags: samplingFlags{topPs: []string{"0.9", "generate=0.95"}},
+			want: func() map[types.Phase]types.SamplingParams {
+				m := every(types.SamplingParams{TopP: f(0.9)})
+				m[types.PhaseGenerate] = types.SamplingParams{TopP: f(0.95)}
+				return m
+			}(),
+		},
+		{
+			name:  "stop sequences accumulate",
+			flags: samplingFlags{stops: []string{"vote=###", "vote=END"}},
+			want:  map[types.Phase]types.SamplingParams{types.PhaseVote: {StopSequences: []string{"###", "END"}}},
+		},
+		{
+			name:  "thinking budget",
+			flags: samplingFlags{thinking: []string{"vote=2048"}},
+			want:  map[types.Phase]types.SamplingParams{types.PhaseVote: {ThinkingBudget: 2048}},
+		},
+		{name: "unknown phase", flags: samplingFlags{temperatures: []string{"critique=0.5"}}, wantErr: `--temperature: unknown phase "critique"`},
+		{name: "temperature out of range", flags: samplingFlags{temperatures: []string{"1.5"}}, wantErr: "--temperature 1.5: must be a number between 0 and 1"},
+		{name: "top_p not a number", flags: samplingFlags{topPs: []string{"vote=high"}}, wantErr: "--top-p vote=high"},
+		{name: "max tokens zero", flags: samplingFlags{maxTokens: []string{"0"}}, wantErr: "must be a positive integer"},
+		{name: "empty stop", flags: samplingFlags{stops: []string{"vote="}}, wantErr: "stop sequence cannot be empty"},
+		{name: "thinking too small", flags: samplingFlags{thinking: []string{"512"}}, wantErr: "at least 1024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSampling(tt.flags)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSampling: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sampling = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSampling(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name    string
+		params  types.SamplingParams
+		phase   types.Phase
+		wantErr string
+	}{
+		{name: "plain sampling", params: types.SamplingParams{Temperature: f(0.5), TopP: f(0.9), MaxTokens: 100}},
+		{name: "thinking with room to answer", params: types.SamplingParams{ThinkingBudget: 2048, MaxTokens: 4096, TopP: f(0.95)}},
+		{name: "thinking with the default max tokens", params: types.SamplingParams{ThinkingBudget: 8192}},
+		{name: "unknown phase", phase: "critique", wantErr: `unknown sampling phase "critique"`},
+		{name: "temperature out of range", params: types.SamplingParams{Temperature: f(-0.1)}, wantErr: "temperature must be between 0 and 1"},
+		{name: "top_p out of range", params: types.SamplingParams{TopP: f(1.1)}, wantErr: "top_p must be between 0 and 1"},
+		{name: "negative max tokens", params: types.SamplingParams{MaxTokens: -1}, wantErr: "max_tokens must be positive"},
+		{name: "thinking budget too small", params: types.SamplingParams{ThinkingBudget: 100}, wantErr: "thinking budget must be at least 1024"},
+		{name: "thinking with temperature", params: types.SamplingParams{ThinkingBudget: 2048, Temperature: f(0.5)}, wantErr: "temperature cannot be combined with thinking"},
+		{name: "thinking with low top_p", params: types.SamplingParams{ThinkingBudget: 2048, TopP: f(0.9)}, wantErr: "top_p must be at least 0.95 with thinking"},
+		{name: "thinking budget fills max tokens", params: types.SamplingParams{ThinkingBudget: 2048, MaxTokens: 2048}, wantErr: "must be greater than the thinking budget"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phase := tt.phase
+			if phase == "" {
+				phase = types.PhaseVote
+			}
+			err := validateSampling(map[types.Phase]types.SamplingParams{phase: tt.params})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -5,17 +5,25 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/humzahkiani/council/internal/prompt"
 	"github.com/humzahkiani/council/internal/types"
 )
 
 // Agent represents a single Claude instance in the council
 type Agent struct {
-	ID     int
-	Total  int
-	client *Client
+	ID       int
+	Total    int
+	Persona  *types.Persona // Optional role that specialises the prompts
+	Prompts  *prompt.Set    // Phase templates; nil uses the built-in defaults
+	Sampling map[types.Phase]types.SamplingParams
+	Context  string            // Packed context files, given to the agent in every phase
+	Images   []ContentBlock    // Attached images, given to the agent in every phase
+	Rubric   []types.Criterion // Criteria to score solutions on when voting; empty for rankings only
+	client   *Client
 }
 
 // New creates a new agent
@@ -27,34 +35,60 @@ func New(id, total int, client *Client) *Agent {
 	}
 }
 
+// NewModerator creates the moderator, which summarises discussion rounds but has no solution or vote
+func NewModerator(total int, client *Client) *Agent {
+	return New(types.ModeratorID, total, client)
+}
+
+// NewJudge creates the nth of the separate judges (from 1), which critique and vote but have no solution.
+// Judges are numbered after the total solving agents.
+func NewJudge(n, total int, client *Client) *Agent {
+	return New(total+n, total, client)
+}
+
 // GenerateSolution creates a solution for the given task
 func (a *Agent) GenerateSolution(ctx context.Context, task string) (*types.Solution, error) {
-	system := a.generationPrompt()
-	messages := []Message{
-		{Role: "user", Content: task},
+	system, err := a.systemPrompt(prompt.Generate, prompt.Data{Task: task})
+	if err != nil {
+		return nil, err
 	}
 
-	response, err := a.client.SendMessage(ctx, system, messages)
+	messages := []Message{a.userMessage(a.formatGenerationRequest(task))}
+
+	response, err := a.client.SendMessage(ctx, system, messages, a.Sampling[types.PhaseGenerate])
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate solution: %w", err)
 	}
 
 	return &types.Solution{
 		AgentID:   a.ID,
-		Content:   response,
+		Content:   response.Text,
+		Truncated: response.Truncated,
+		Thinking:  response.Thinking,
 		CreatedAt: time.Now(),
 	}, nil
 }
 
-// Critique generates critiques of all solutions
-func (a *Agent) Critique(ctx context.Context, task string, solutions []types.Solution, round int) (*types.Critique, error) {
-	system := a.discussionPrompt()
-	userContent := a.formatDiscussionRequest(task, solutions)
-	messages := []Message{
-		{Role: "user", Content: userContent},
+// Critique generates critiques of all solutions. shared are critiques given
+// before the round for the agent to take into account, such as a human's
+// review, and note is the moderator's summary of the previous round, nil if there is none.
+func (a *Agent) Critique(ctx context.Context, task string, solutions []types.Solution, shared []types.Critique, round int, note *types.ModeratorNote) (*types.Critique, error) {
+	system, err := a.systemPrompt(prompt.Discuss, prompt.Data{
+		Task:       task,
+		Solutions:  solutions,
+		Critiques:  shared,
+		Round:      round,
+		Tested:     tested(solutions),
+		Moderation: note,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	response, err := a.client.SendMessage(ctx, system, messages)
+	userContent := a.formatDiscussionRequest(task, solutions, shared, note)
+	messages := []Message{a.userMessage(userContent)}
+
+	response, err := a.client.SendMessage(ctx, system, messages, a.Sampling[types.PhaseDiscuss])
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate critique: %w", err)
 	}
@@ -62,115 +96,543 @@ func (a *Agent) Critique(ctx context.Context, task string, solutions []types.Sol
 	return &types.Critique{
 		AgentID:   a.ID,
 		Round:     round,
-		Content:   response,
+		Content:   response.Text,
+		Truncated: response.Truncated,
+		Thinking:  response.Thinking,
 		CreatedAt: time.Now(),
 	}, nil
 }
 
-// Vote ranks all solutions except the agent's own
-func (a *Agent) Vote(ctx context.Context, task string, solutions []types.Solution, critiques []types.Critique) (*types.Vote, error) {
-	system := a.votingPrompt()
-	userContent := a.formatVotingRequest(task, solutions, critiques)
-	messages := []Message{
-		{Role: "user", Content: userContent},
+// Vote ranks all solutions except the agent's own. note is the moderator's
+// summary of the last round, nil if there is none.
+func (a *Agent) Vote(ctx context.Context, task string, solutions []types.Solution, critiques []types.Critique, note *types.ModeratorNote) (*types.Vote, error) {
+	system, err := a.systemPrompt(prompt.Vote, prompt.Data{
+		Task:       task,
+		Solutions:  solutions,
+		Critiques:  critiques,
+		Round:      lastRound(critiques),
+		Tested:     tested(solutions),
+		Moderation: note,
+		Rubric:     a.Rubric,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	response, err := a.client.SendMessage(ctx, system, messages)
+	userContent := a.formatVotingRequest(task, solutions, critiques, note)
+	messages := []Message{a.userMessage(userContent)}
+
+	response, err := a.client.SendMessage(ctx, system, messages, a.Sampling[types.PhaseVote])
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate vote: %w", err)
 	}
 
-	return a.parseVote(response)
+	vote, err := a.parseVote(response.Text, solutions)
+	if err != nil {
+		return nil, err
+	}
+	vote.Thinking = response.Thinking
+	return vote, nil
+}
+
+// Judge compares two solutions head to head, shown in the order given as A then B
+func (a *Agent) Judge(ctx context.Context, task string, first, second types.Solution, critiques []types.Critique, note *types.ModeratorNote) (*types.PairwiseJudgment, error) {
+	// The same no-self-vote rule as ranked voting
+	if first.AgentID == a.ID || second.AgentID == a.ID {
+		return nil, fmt.Errorf("agent %d cannot judge a pair including their own solution", a.ID)
+	}
+
+	pair := []types.Solution{first, second}
+	system, err := a.systemPrompt(prompt.Judge, prompt.Data{
+		Task:       task,
+		Solutions:  pair,
+		Critiques:  critiques,
+		Round:      lastRound(critiques),
+		Tested:     tested(pair),
+		Moderation: note,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	userContent := a.formatJudgingRequest(task, first, second, critiques, note)
+	messages := []Message{a.userMessage(userContent)}
+
+	response, err := a.client.SendMessage(ctx, system, messages, a.Sampling[types.PhaseVote])
+	if err != nil {
+		return nil, fmt.Errorf("failed to judge pair: %w", err)
+	}
+
+	judgment, err := a.parseJudgment(response.Text, first.AgentID, second.AgentID)
+	if err != nil {
+		return nil, err
+	}
+	judgment.Thinking = response.Thinking
+	return judgment, nil
 }
 
-// generationPrompt returns the system prompt for solution generation
-func (a *Agent) generationPrompt() string {
-	return fmt.Sprintf(`You are Agent %d in a council of %d agents. You have been given a task to solve.
+// Moderate summarises a discussion round from its critiques, poses follow-up
+// questions and decides whether another round is needed. finalRound is true
+// after the last configured round, when there is nothing left to decide.
+func (a *Agent) Moderate(ctx context.Context, task string, solutions []types.Solution, critiques []types.Critique, round int, finalRound bool) (*types.ModeratorNote, error) {
+	system, err := a.systemPrompt(prompt.Moderate, prompt.Data{
+		Task:       task,
+		Solutions:  solutions,
+		Critiques:  critiques,
+		Round:      round,
+		Tested:     tested(solutions),
+		FinalRound: finalRound,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	userContent := a.formatModerationRequest(task, solutions, critiques)
+	messages := []Message{a.userMessage(userContent)}
 
-Provide your solution to the task. Be thorough but concise. Focus on correctness and clarity.
+	response, err := a.client.SendMessage(ctx, system, messages, a.Sampling[types.PhaseModerate])
+	if err != nil {
+		return nil, fmt.Errorf("failed to moderate: %w", err)
+	}
 
-Do not reference other agents or solutions — you are working independently.`, a.ID, a.Total)
+	note, err := a.parseModeration(response.Text)
+	if err != nil {
+		return nil, err
+	}
+	note.Round = round
+	note.Thinking = response.Thinking
+	if finalRound {
+		note.Questions = nil
+		note.Continue = false
+	}
+	return note, nil
 }
 
-// discussionPrompt returns the system prompt for discussion/critique
-func (a *Agent) discussionPrompt() string {
-	return fmt.Sprintf(`You are Agent %d in a council of %d agents.
+// Synthesize merges the top-ranked solutions into a final answer
+func (a *Agent) Synthesize(ctx context.Context, task string, solutions []types.Solution, critiques []types.Critique, standings []types.Standing) (*types.Synthesis, error) {
+	system, err := a.systemPrompt(prompt.Synthesize, prompt.Data{
+		Task:      task,
+		Solutions: solutions,
+		Critiques: critiques,
+		Round:     lastRound(critiques),
+		Tested:    tested(solutions),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	userContent := a.formatSynthesisRequest(task, solutions, critiques, standings)
+	messages := []Message{a.userMessage(userContent)}
+
+	response, err := a.client.SendMessage(ctx, system, messages, a.Sampling[types.PhaseSynthesize])
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate synthesis: %w", err)
+	}
 
-Review all solutions and provide your critique. For each solution OTHER than your own:
-- Identify strengths
-- Identify weaknesses or potential issues
-- Suggest improvements if applicable
+	sourceIDs := make([]int, len(solutions))
+	for i, sol := range solutions {
+		sourceIDs[i] = sol.AgentID
+	}
 
-Be constructive and objective. Your goal is to help identify the best solution.`, a.ID, a.Total)
+	return &types.Synthesis{
+		AgentID:   a.ID,
+		SourceIDs: sourceIDs,
+		Content:   response.Text,
+		Truncated: response.Truncated,
+		Thinking:  response.Thinking,
+		CreatedAt: time.Now(),
+	}, nil
 }
 
-// votingPrompt returns the system prompt for voting
-func (a *Agent) votingPrompt() string {
-	return fmt.Sprintf(`You are Agent %d in a council of %d agents. You have seen all solutions and the discussion.
+// systemPrompt renders the phase template for this agent
+func (a *Agent) systemPrompt(name prompt.Name, data prompt.Data) (string, error) {
+	prompts := a.Prompts
+	if prompts == nil {
+		prompts = prompt.Default()
+	}
 
-Rank all solutions EXCEPT YOUR OWN from best to worst. You CANNOT vote for your own solution (Solution %d).
+	data.AgentID = a.ID
+	data.Name = a.name()
+	data.Total = a.Total
+	data.Judge = a.judge()
+	data.Persona = a.Persona
+	data.PersonaInstructions = a.personaInstructions(name)
 
-Respond with a JSON object in this exact format:
-{
-  "rankings": [X, Y, ...],
-  "reasoning": "Brief explanation of your ranking"
+	return prompts.Render(name, data)
 }
 
-Where X is the agent number of your top choice, Y is your second choice, etc.
-Do not include your own agent number (%d) in the rankings.`, a.ID, a.Total, a.ID, a.ID)
+// name returns how the agent refers to itself in prompts
+func (a *Agent) name() string {
+	if a.ID == types.ModeratorID {
+		return "the Moderator"
+	}
+	if a.Persona != nil {
+		return fmt.Sprintf("%s (%s)", a.label(a.ID), a.Persona.Name)
+	}
+	return a.label(a.ID)
+}
+
+// judge reports whether the agent is a separate judge rather than one of the solvers
+func (a *Agent) judge() bool {
+	return a.ID > a.Total
+}
+
+// label names an agent, judge or the human by ID, e.g. "Agent 2" or "Judge 1"
+func (a *Agent) label(id int) string {
+	if id == types.HumanID {
+		return "Human"
+	}
+	if id > a.Total {
+		return fmt.Sprintf("Judge %d", id-a.Total)
+	}
+	return fmt.Sprintf("Agent %d", id)
+}
+
+// personaInstructions combines the persona's general and phase-specific instructions
+func (a *Agent) personaInstructions(name prompt.Name) string {
+	if a.Persona == nil {
+		return ""
+	}
+
+	var extra string
+	switch name {
+	case prompt.Generate, prompt.Synthesize:
+		extra = a.Persona.Generate
+	case prompt.Discuss:
+		extra = a.Persona.Critique
+	case prompt.Vote, prompt.Judge:
+		extra = a.Persona.Vote
+	}
+
+	var parts []string
+	for _, part := range []string{a.Persona.Instructions, extra} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// userMessage builds the user turn for a phase, placing attached images before the text
+func (a *Agent) userMessage(text string) Message {
+	content := make([]ContentBlock, 0, len(a.Images)+1)
+	content = append(content, a.Images...)
+	content = append(content, TextBlock(text))
+	return Message{Role: "user", Content: content}
+}
+
+// formatGenerationRequest formats the user message for solution generation
+func (a *Agent) formatGenerationRequest(task string) string {
+	if a.Context == "" {
+		return task
+	}
+
+	var sb strings.Builder
+	a.writeContext(&sb)
+	sb.WriteString("## Task\n")
+	sb.WriteString(task)
+	return sb.String()
+}
+
+// writeContext writes the attached context section, if any
+func (a *Agent) writeContext(sb *strings.Builder) {
+	if a.Context == "" {
+		return
+	}
+	sb.WriteString("## Context\n")
+	sb.WriteString(a.Context)
+	sb.WriteString("\n\n")
 }
 
 // formatDiscussionRequest formats the user message for discussion
-func (a *Agent) formatDiscussionRequest(task string, solutions []types.Solution) string {
+func (a *Agent) formatDiscussionRequest(task string, solutions []types.Solution, shared []types.Critique, note *types.ModeratorNote) string {
 	var sb strings.Builder
+	a.writeContext(&sb)
 	sb.WriteString("## Task\n")
 	sb.WriteString(task)
 	sb.WriteString("\n\n## Solutions\n\n")
 
 	for _, sol := range solutions {
-		sb.WriteString(fmt.Sprintf("### Solution %d (Agent %d)\n", sol.AgentID, sol.AgentID))
-		sb.WriteString(sol.Content)
-		sb.WriteString("\n\n")
+		writeSolution(&sb, sol)
 	}
 
+	a.writeCritiques(&sb, shared)
+	a.writeModeration(&sb, note)
+
 	return sb.String()
 }
 
+// writeModeration writes the moderator's summary and the questions it put to this agent, if there is a note
+func (a *Agent) writeModeration(sb *strings.Builder, note *types.ModeratorNote) {
+	if note == nil {
+		return
+	}
+	sb.WriteString(fmt.Sprintf("## Moderator's Summary of Round %d\n", note.Round))
+	sb.WriteString(note.Summary)
+	sb.WriteString("\n\n")
+
+	if len(note.Disagreements) > 0 {
+		sb.WriteString("### Open Disagreements\n")
+		for _, d := range note.Disagreements {
+			sb.WriteString(fmt.Sprintf("- %s\n", d))
+		}
+		sb.WriteString("\n")
+	}
+
+	var questions []string
+	for _, q := range note.Questions {
+		if q.AgentID == a.ID {
+			questions = append(questions, q.Question)
+		}
+	}
+	if len(questions) > 0 {
+		sb.WriteString("### Questions for You\n")
+		for _, q := range questions {
+			sb.WriteString(fmt.Sprintf("- %s\n", q))
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// writeSolution writes one solution, followed by its test results if it has any
+func writeSolution(sb *strings.Builder, sol types.Solution) {
+	writeSolutionAs(sb, fmt.Sprint(sol.AgentID), sol)
+}
+
+// writeSolutionAs writes one solution under the given label, e.g. "A" when judging a pair
+func writeSolutionAs(sb *strings.Builder, label string, sol types.Solution) {
+	sb.WriteString(fmt.Sprintf("### Solution %s (Agent %d)\n", label, sol.AgentID))
+	sb.WriteString(sol.Content)
+	sb.WriteString("\n\n")
+
+	v := sol.Verification
+	if v == nil {
+		return
+	}
+	switch {
+	case v.Passed:
+		sb.WriteString("**Test results: PASSED**\n")
+	case v.Error != "":
+		sb.WriteString(fmt.Sprintf("**Test results: ERROR** (%s)\n", v.Error))
+	default:
+		sb.WriteString(fmt.Sprintf("**Test results: FAILED** (exit code %d)\n", v.ExitCode))
+	}
+	if output := strings.TrimSpace(v.Output); output != "" {
+		sb.WriteString("```\n")
+		sb.WriteString(output)
+		sb.WriteString("\n```\n")
+	}
+	sb.WriteString("\n")
+}
+
 // formatVotingRequest formats the user message for voting
-func (a *Agent) formatVotingRequest(task string, solutions []types.Solution, critiques []types.Critique) string {
+func (a *Agent) formatVotingRequest(task string, solutions []types.Solution, critiques []types.Critique, note *types.ModeratorNote) string {
 	var sb strings.Builder
+	a.writeContext(&sb)
 	sb.WriteString("## Task\n")
 	sb.WriteString(task)
 	sb.WriteString("\n\n## Solutions\n\n")
 
 	for _, sol := range solutions {
-		sb.WriteString(fmt.Sprintf("### Solution %d (Agent %d)\n", sol.AgentID, sol.AgentID))
-		sb.WriteString(sol.Content)
+		writeSolution(&sb, sol)
+	}
+
+	a.writeCritiques(&sb, critiques)
+	a.writeModeration(&sb, note)
+
+	if len(a.Rubric) > 0 {
+		names := make([]string, len(a.Rubric))
+		for i, c := range a.Rubric {
+			names[i] = c.Name
+		}
+		sb.WriteString(fmt.Sprintf("Score each solution you rank on: %s.\n", strings.Join(names, ", ")))
+	}
+
+	if a.judge() {
+		sb.WriteString("Now provide your vote, ranking every solution.\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("Now provide your vote. Remember: you are Agent %d and cannot vote for your own solution.\n", a.ID))
+	}
+
+	return sb.String()
+}
+
+// writeCritiques writes the discussion section, if there were any critiques
+func (a *Agent) writeCritiques(sb *strings.Builder, critiques []types.Critique) {
+	if len(critiques) == 0 {
+		return
+	}
+	sb.WriteString("## Discussion\n\n")
+	for _, crit := range critiques {
+		sb.WriteString(fmt.Sprintf("### %s's Critique\n", a.label(crit.AgentID)))
+		sb.WriteString(crit.Content)
 		sb.WriteString("\n\n")
 	}
+}
+
+// formatJudgingRequest formats the user message for judging a pair of solutions
+func (a *Agent) formatJudgingRequest(task string, first, second types.Solution, critiques []types.Critique, note *types.ModeratorNote) string {
+	var sb strings.Builder
+	a.writeContext(&sb)
+	sb.WriteString("## Task\n")
+	sb.WriteString(task)
+	sb.WriteString("\n\n## Solutions\n\n")
+
+	writeSolutionAs(&sb, "A", first)
+	writeSolutionAs(&sb, "B", second)
+
+	a.writeCritiques(&sb, critiques)
+	a.writeModeration(&sb, note)
+
+	sb.WriteString("Now judge which solution is better: A or B.\n")
+
+	return sb.String()
+}
+
+// formatModerationRequest formats the user message for moderating a round
+func (a *Agent) formatModerationRequest(task string, solutions []types.Solution, critiques []types.Critique) string {
+	var sb strings.Builder
+	a.writeContext(&sb)
+	sb.WriteString("## Task\n")
+	sb.WriteString(task)
+	sb.WriteString("\n\n## Solutions\n\n")
+
+	for _, sol := range solutions {
+		writeSolution(&sb, sol)
+	}
 
-	if len(critiques) > 0 {
-		sb.WriteString("## Discussion\n\n")
-		for _, crit := range critiques {
-			sb.WriteString(fmt.Sprintf("### Agent %d's Critique\n", crit.AgentID))
-			sb.WriteString(crit.Content)
-			sb.WriteString("\n\n")
+	a.writeCritiques(&sb, critiques)
+
+	sb.WriteString("Now summarise this round as the moderator.\n")
+
+	return sb.String()
+}
+
+// formatSynthesisRequest formats the user message for synthesis
+func (a *Agent) formatSynthesisRequest(task string, solutions []types.Solution, critiques []types.Critique, standings []types.Standing) string {
+	var sb strings.Builder
+	a.writeContext(&sb)
+	sb.WriteString("## Task\n")
+	sb.WriteString(task)
+	sb.WriteString("\n\n## Top Solutions\n\n")
+
+	labels := make(map[int]string, len(standings))
+	for _, st := range standings {
+		labels[st.AgentID] = st.Label
+	}
+	for _, sol := range solutions {
+		if label, ok := labels[sol.AgentID]; ok {
+			sb.WriteString(fmt.Sprintf("Score: %s\n", label))
 		}
+		writeSolution(&sb, sol)
+	}
+
+	a.writeCritiques(&sb, critiques)
+
+	sb.WriteString("## Tally\n\n")
+	for _, st := range standings {
+		sb.WriteString(fmt.Sprintf("- Agent %d: %s\n", st.AgentID, st.Label))
 	}
 
-	sb.WriteString(fmt.Sprintf("Now provide your vote. Remember: you are Agent %d and cannot vote for your own solution.\n", a.ID))
+	sb.WriteString("\nNow write the final, merged answer to the task.\n")
 
 	return sb.String()
 }
 
 // voteResponse represents the expected JSON structure of a vote
 type voteResponse struct {
-	Rankings  []int  `json:"rankings"`
+	Rankings   []int                         `json:"rankings"`
+	Reasoning  string                        `json:"reasoning"`
+	Scores     map[string]map[string]float64 `json:"scores"`
+	Confidence *float64                      `json:"confidence"`
+	Abstain    bool                          `json:"abstain"`
+}
+
+// judgmentResponse represents the expected JSON structure of a pairwise judgment
+type judgmentResponse struct {
+	Winner    string `json:"winner"`
 	Reasoning string `json:"reasoning"`
 }
 
-// parseVote extracts and validates a vote from the agent's response
-func (a *Agent) parseVote(response string) (*types.Vote, error) {
+// parseJudgment extracts and validates a pairwise judgment from the response
+func (a *Agent) parseJudgment(response string, first, second int) (*types.PairwiseJudgment, error) {
+	jsonStr := extractJSON(response)
+	if jsonStr == "" {
+		return nil, fmt.Errorf("no JSON found in response")
+	}
+
+	var resp judgmentResponse
+	if err := json.Unmarshal([]byte(jsonStr), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse judgment JSON: %w", err)
+	}
+
+	judgment := &types.PairwiseJudgment{
+		VoterID:   a.ID,
+		A:         first,
+		B:         second,
+		Reasoning: resp.Reasoning,
+	}
+	switch strings.ToUpper(strings.TrimSpace(resp.Winner)) {
+	case "A":
+		judgment.WinnerID = first
+	case "B":
+		judgment.WinnerID = second
+	default:
+		return nil, fmt.Errorf("invalid winner in judgment: %q", resp.Winner)
+	}
+
+	return judgment, nil
+}
+
+// moderationResponse represents the expected JSON structure of a moderator note
+type moderationResponse struct {
+	Summary       string   `json:"summary"`
+	Disagreements []string `json:"disagreements"`
+	Questions     []struct {
+		Agent    int    `json:"agent"`
+		Question string `json:"question"`
+	} `json:"questions"`
+	Continue bool   `json:"continue"`
+	Reason   string `json:"reason"`
+}
+
+// parseModeration extracts and validates a moderator note from the response
+func (a *Agent) parseModeration(response string) (*types.ModeratorNote, error) {
+	jsonStr := extractJSON(response)
+	if jsonStr == "" {
+		return nil, fmt.Errorf("no JSON found in moderator response")
+	}
+
+	var resp moderationResponse
+	if err := json.Unmarshal([]byte(jsonStr), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse moderator JSON: %w", err)
+	}
+
+	if strings.TrimSpace(resp.Summary) == "" {
+		return nil, fmt.Errorf("moderator response has no summary")
+	}
+
+	note := &types.ModeratorNote{
+		Summary:       resp.Summary,
+		Disagreements: resp.Disagreements,
+		Continue:      resp.Continue,
+		Reason:        resp.Reason,
+		CreatedAt:     time.Now(),
+	}
+	for _, q := range resp.Questions {
+		if q.Agent < 1 || q.Agent > a.Total {
+			return nil, fmt.Errorf("invalid agent ID in moderator questions: %d", q.Agent)
+		}
+		note.Questions = append(note.Questions, types.ModeratorQuestion{AgentID: q.Agent, Question: q.Question})
+	}
+
+	return note, nil
+}
+
+// parseVote extracts and validates a vote from the agent's response. Rankings
+// may only name the agents whose solutions were put to the vote; a voter that
+// can't judge them abstains instead.
+func (a *Agent) parseVote(response string, solutions []types.Solution) (*types.Vote, error) {
 	jsonStr := extractJSON(response)
 	if jsonStr == "" {
 		return nil, fmt.Errorf("no JSON found in response")
@@ -181,6 +643,30 @@ func (a *Agent) parseVote(response string) (*types.Vote, error) {
 		return nil, fmt.Errorf("failed to parse vote JSON: %w", err)
 	}
 
+	// An abstention must be unambiguous: no rankings alongside it, and no empty ranking without it
+	if voteResp.Abstain {
+		if len(voteResp.Rankings) > 0 {
+			return nil, fmt.Errorf("vote both abstains and ranks solutions")
+		}
+		return &types.Vote{
+			VoterID:   a.ID,
+			Rankings:  []int{},
+			Reasoning: voteResp.Reasoning,
+			Status:    types.VoteAbstained,
+		}, nil
+	}
+	if len(voteResp.Rankings) == 0 {
+		return nil, fmt.Errorf("vote ranks no solutions and does not abstain")
+	}
+
+	var confidence float64
+	if voteResp.Confidence != nil {
+		confidence = *voteResp.Confidence
+		if confidence <= 0 || confidence > 1 {
+			return nil, fmt.Errorf("vote confidence must be above 0 and at most 1 (got %g)", confidence)
+		}
+	}
+
 	// Validate: ensure agent did not vote for themselves
 	for _, ranking := range voteResp.Rankings {
 		if ranking == a.ID {
@@ -188,20 +674,89 @@ func (a *Agent) parseVote(response string) (*types.Vote, error) {
 		}
 	}
 
-	// Validate: ensure rankings are valid agent IDs (1 to Total, excluding self)
+	// Validate: ensure rankings are agent IDs of the solutions being voted on
+	candidates := make(map[int]bool)
+	for _, sol := range solutions {
+		candidates[sol.AgentID] = true
+	}
 	for _, ranking := range voteResp.Rankings {
-		if ranking < 1 || ranking > a.Total {
+		if !candidates[ranking] {
 			return nil, fmt.Errorf("invalid agent ID in rankings: %d", ranking)
 		}
 	}
 
+	scores, err := a.parseScores(voteResp.Scores, candidates)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.Vote{
-		VoterID:   a.ID,
-		Rankings:  voteResp.Rankings,
-		Reasoning: voteResp.Reasoning,
+		VoterID:    a.ID,
+		Rankings:   voteResp.Rankings,
+		Reasoning:  voteResp.Reasoning,
+		Scores:     scores,
+		Confidence: confidence,
 	}, nil
 }
 
+// parseScores validates rubric scores against the candidates and criteria.
+// Scores are ignored without a rubric; solutions or criteria left unscored are allowed.
+func (a *Agent) parseScores(raw map[string]map[string]float64, candidates map[int]bool) (map[int]map[string]float64, error) {
+	if len(a.Rubric) == 0 || len(raw) == 0 {
+		return nil, nil
+	}
+
+	criteria := make(map[string]bool)
+	for _, c := range a.Rubric {
+		criteria[c.Name] = true
+	}
+
+	scores := make(map[int]map[string]float64)
+	for key, byCriterion := range raw {
+		id, err := strconv.Atoi(strings.TrimSpace(key))
+		if err != nil || !candidates[id] {
+			return nil, fmt.Errorf("invalid agent ID in scores: %q", key)
+		}
+		if id == a.ID {
+			return nil, fmt.Errorf("agent %d scored their own solution", a.ID)
+		}
+		for name, score := range byCriterion {
+			if !criteria[name] {
+				return nil, fmt.Errorf("unknown rubric criterion in scores: %q", name)
+			}
+			if score < types.RubricMin || score > types.RubricMax {
+				return nil, fmt.Errorf("%s score for agent %d out of range: %g", name, id, score)
+			}
+		}
+		if len(byCriterion) > 0 {
+			scores[id] = byCriterion
+		}
+	}
+
+	return scores, nil
+}
+
+// tested reports whether the solutions carry test results
+func tested(solutions []types.Solution) bool {
+	for _, sol := range solutions {
+		if sol.Verification != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// lastRound returns the latest discussion round among the critiques
+func lastRound(critiques []types.Critique) int {
+	round := 0
+	for _, crit := range critiques {
+		if crit.Round > round {
+			round = crit.Round
+		}
+	}
+	return round
+}
+
 // extractJSON extracts a JSON object from text that may contain markdown or other content
 func extractJSON(s string) string {
 	// First, try to find JSON in a code block
@@ -0,0 +1,134 @@
+package agent
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+func TestParseVote(t *testing.T) {
+	solutions := []types.Solution{{AgentID: 1}, {AgentID: 2}, {AgentID: 3}}
+
+	tests := []struct {
+		name       string
+		response   string
+		rubric     []types.Criterion
+		wantErr    string
+		rankings   []int
+		status     types.VoteStatus
+		confidence float64
+	}{
+		{
+			name:       "ranked with confidence",
+			response:   `{"rankings": [3, 1], "reasoning": "3 is tested", "confidence": 0.8}`,
+			rankings:   []int{3, 1},
+			confidence: 0.8,
+		},
+		{
+			name:     "confidence is optional",
+			response: "My vote:\n```json\n{\"rankings\": [1, 3], \"reasoning\": \"ok\"}\n```",
+			rankings: []int{1, 3},
+		},
+		{
+			name:     "abstain",
+			response: `{"abstain": true, "rankings": [], "reasoning": "outside my expertise"}`,
+			rankings: []int{},
+			status:   types.VoteAbstained,
+		},
+		{
+			name:     "abstain that also ranks",
+			response: `{"abstain": true, "rankings": [1, 3], "reasoning": "unsure"}`,
+			wantErr:  "both abstains and ranks",
+		},
+		{
+			name:     "empty ranking without abstaining",
+			response: `{"rankings": [], "reasoning": "none are good"}`,
+			wantErr:  "ranks no solutions and does not abstain",
+		},
+		{
+			name:     "zero confidence",
+			response: `{"rankings": [1, 3], "confidence": 0}`,
+			wantErr:  "confidence must be above 0",
+		},
+		{
+			name:     "confidence above 1",
+			response: `{"rankings": [1, 3], "confidence": 1.5}`,
+			wantErr:  "confidence must be above 0",
+		},
+		{
+			name:     "votes for itself",
+			response: `{"rankings": [2, 1]}`,
+			wantErr:  "voted for their own solution",
+		},
+		{
+			name:     "unknown solution",
+			response: `{"rankings": [1, 7]}`,
+			wantErr:  "invalid agent ID in rankings: 7",
+		},
+		{
+			name:     "rubric score out of range",
+			response: `{"rankings": [1, 3], "scores": {"1": {"correctness": 11}}}`,
+			rubric:   []types.Criterion{{Name: "correctness", Weight: 1}},
+			wantErr:  "correctness",
+		},
+		{
+			name:     "no JSON",
+			response: "I prefer agent 1.",
+			wantErr:  "no JSON found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New(2, 3, nil)
+			a.Rubric = tt.rubric
+
+			vote, err := a.parseVote(tt.response, solutions)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if vote.VoterID != 2 || !reflect.DeepEqual(vote.Rankings, tt.rankings) || vote.Status != tt.status || vote.Confidence != tt.confidence {
+				t.Errorf("vote = %+v, want rankings %v, status %q, confidence %g", vote, tt.rankings, tt.status, tt.confidence)
+			}
+		})
+	}
+}
+
+func TestFormatDiscussionRequest(t *testing.T) {
+	solutions := []types.Solution{{AgentID: 1, Content: "one"}, {AgentID: 2, Content: "two"}}
+	human := []types.Critique{{AgentID: types.HumanID, Round: 1, Content: "A misses the retry limit"}}
+
+	tests := []struct {
+		name   string
+		shared []types.Critique
+		want   []string
+		reject []string
+	}{
+		{name: "no shared critiques", reject: []string{"## Discussion"}},
+		{name: "human critique", shared: human, want: []string{"## Discussion", "### Human's Critique", "A misses the retry limit"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := New(2, 3, nil).formatDiscussionRequest("task", solutions, tt.shared, nil)
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("request missing %q:\n%s", want, out)
+				}
+			}
+			for _, reject := range tt.reject {
+				if strings.Contains(out, reject) {
+					t.Errorf("request contains %q:\n%s", reject, out)
+				}
+			}
+		})
+	}
+}
@@ -7,15 +7,21 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/humzahkiani/council/internal/types"
 )
 
 const (
-	defaultBaseURL    = "https://api.anthropic.com"
-	anthropicVersion  = "2023-06-01"
-	defaultMaxTokens  = 4096
-	maxRetries        = 3
-	baseRetryDelay    = 1 * time.Second
+	defaultBaseURL   = "https://api.anthropic.com"
+	anthropicVersion = "2023-06-01"
+	defaultMaxTokens = 4096
+	maxRetries       = 3
+	baseRetryDelay   = 1 * time.Second
+	maxContinuations = 3
 )
 
 // Client handles communication with the Anthropic API
@@ -24,20 +30,69 @@ type Client struct {
 	baseURL    string
 	model      string
 	httpClient *http.Client
+
+	mu    sync.Mutex
+	usage types.Usage // Tokens used by every successful request so far
 }
 
 // Message represents a conversation message
 type Message struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role    string         `json:"role"`
+	Content []ContentBlock `json:"content"`
+}
+
+// ContentBlock is one part of a message's content: text or an image
+type ContentBlock struct {
+	Type   string       `json:"type"`
+	Text   string       `json:"text,omitempty"`
+	Source *ImageSource `json:"source,omitempty"`
+}
+
+// ImageSource holds base64-encoded image data for an image block
+type ImageSource struct {
+	Type      string `json:"type"`
+	MediaType string `json:"media_type"`
+	Data      string `json:"data"`
+}
+
+// TextBlock returns a text content block
+func TextBlock(text string) ContentBlock {
+	return ContentBlock{Type: "text", Text: text}
+}
+
+// ImageBlock returns an image content block from base64-encoded data
+func ImageBlock(mediaType, data string) ContentBlock {
+	return ContentBlock{
+		Type:   "image",
+		Source: &ImageSource{Type: "base64", MediaType: mediaType, Data: data},
+	}
+}
+
+// Response is the text of a completed message along with how it finished
+type Response struct {
+	Text          string
+	Thinking      string // Extended thinking blocks, if thinking was enabled
+	StopReason    string
+	Continuations int  // Extra requests made to continue past max_tokens
+	Truncated     bool // Still stopped at max_tokens after all continuations
 }
 
 // messageRequest represents an API request to the messages endpoint
 type messageRequest struct {
-	Model     string    `json:"model"`
-	MaxTokens int       `json:"max_tokens"`
-	System    string    `json:"system,omitempty"`
-	Messages  []Message `json:"messages"`
+	Model         string    `json:"model"`
+	MaxTokens     int       `json:"max_tokens"`
+	System        string    `json:"system,omitempty"`
+	Messages      []Message `json:"messages"`
+	Temperature   *float64  `json:"temperature,omitempty"`
+	TopP          *float64  `json:"top_p,omitempty"`
+	StopSequences []string  `json:"stop_sequences,omitempty"`
+	Thinking      *thinking `json:"thinking,omitempty"`
+}
+
+// thinking enables extended thinking with a token budget
+type thinking struct {
+	Type         string `json:"type"`
+	BudgetTokens int    `json:"budget_tokens"`
 }
 
 // messageResponse represents an API response from the messages endpoint
@@ -46,8 +101,9 @@ type messageResponse struct {
 	Type    string `json:"type"`
 	Role    string `json:"role"`
 	Content []struct {
-		Type string `json:"type"`
-		Text string `json:"text"`
+		Type     string `json:"type"`
+		Text     string `json:"text"`
+		Thinking string `json:"thinking"`
 	} `json:"content"`
 	StopReason string `json:"stop_reason"`
 	Usage      struct {
@@ -65,11 +121,17 @@ type errorResponse struct {
 	} `json:"error"`
 }
 
-// NewClient creates a new Anthropic API client
+// NewClient creates a new Anthropic API client. ANTHROPIC_BASE_URL, if set,
+// replaces the API's address, e.g. to go through a proxy.
 func NewClient(apiKey, model string) *Client {
+	baseURL := defaultBaseURL
+	if url := os.Getenv("ANTHROPIC_BASE_URL"); url != "" {
+		baseURL = strings.TrimRight(url, "/")
+	}
+
 	return &Client{
 		apiKey:  apiKey,
-		baseURL: defaultBaseURL,
+		baseURL: baseURL,
 		model:   model,
 		httpClient: &http.Client{
 			Timeout: 120 * time.Second,
@@ -77,15 +139,63 @@ func NewClient(apiKey, model string) *Client {
 	}
 }
 
-// SendMessage sends a message to Claude and returns the response text
+// SendMessage sends a message to Claude and returns the response text.
+// If the response stops at max_tokens, the partial text is sent back as an
+// assistant prefill so the model can continue, up to maxContinuations times.
+// The API doesn't accept prefills with extended thinking, so thinking
+// responses that hit max_tokens are marked truncated instead.
+func (c *Client) SendMessage(ctx context.Context, system string, messages []Message, params types.SamplingParams) (*Response, error) {
+	result := &Response{}
+	conversation := messages
+	cut := "" // Whitespace left off the end of the last prefill
+
+	for {
+		response, err := c.sendWithRetry(ctx, system, conversation, params)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Text += joinContinuation(cut, c.extractText(response))
+		result.Thinking += c.extractThinking(response)
+		result.StopReason = response.StopReason
+
+		if response.StopReason != "max_tokens" {
+			return result, nil
+		}
+		if params.ThinkingBudget > 0 || result.Continuations >= maxContinuations {
+			result.Truncated = true
+			return result, nil
+		}
+
+		// The API rejects assistant prefills that end in whitespace
+		trimmed := strings.TrimRight(result.Text, " \t\r\n")
+		result.Text, cut = trimmed, result.Text[len(trimmed):]
+		prefill := Message{Role: "assistant", Content: []ContentBlock{TextBlock(result.Text)}}
+		conversation = append(append([]Message{}, messages...), prefill)
+		result.Continuations++
+	}
+}
+
+// joinContinuation returns the text continuing a prefill that had cut trimmed
+// off its end. The model saw the prefill without it, so whitespace the
+// continuation starts with is kept as the model wrote it; otherwise cut is put back.
+func joinContinuation(cut, text string) string {
+	if text == "" || strings.ContainsAny(text[:1], " \t\r\n") {
+		return text
+	}
+	return cut + text
+}
+
+// sendWithRetry performs a single request
 // Implements retry with exponential backoff on rate limits (HTTP 429)
-func (c *Client) SendMessage(ctx context.Context, system string, messages []Message) (string, error) {
+func (c *Client) sendWithRetry(ctx context.Context, system string, messages []Message, params types.SamplingParams) (*messageResponse, error) {
 	var lastErr error
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
-		response, err := c.doRequest(ctx, system, messages)
+		response, err := c.doRequest(ctx, system, messages, params)
 		if err == nil {
-			return c.extractText(response), nil
+			c.addUsage(response)
+			return response, nil
 		}
 
 		lastErr = err
@@ -95,7 +205,7 @@ func (c *Client) SendMessage(ctx context.Context, system string, messages []Mess
 			delay := baseRetryDelay * time.Duration(1<<attempt) // Exponential backoff
 			select {
 			case <-ctx.Done():
-				return "", ctx.Err()
+				return nil, ctx.Err()
 			case <-time.After(delay):
 				continue
 			}
@@ -103,20 +213,50 @@ func (c *Client) SendMessage(ctx context.Context, system string, messages []Mess
 
 		// For non-rate-limit errors, don't retry
 		if !isRateLimitError(err) {
-			return "", err
+			return nil, err
 		}
 	}
 
-	return "", fmt.Errorf("max retries exceeded: %w", lastErr)
+	return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
+}
+
+// addUsage records the tokens used by a response
+func (c *Client) addUsage(resp *messageResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.usage.InputTokens += resp.Usage.InputTokens
+	c.usage.OutputTokens += resp.Usage.OutputTokens
+}
+
+// Usage returns the total tokens used by this client so far
+func (c *Client) Usage() types.Usage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.usage
 }
 
 // doRequest performs the actual HTTP request to the Anthropic API
-func (c *Client) doRequest(ctx context.Context, system string, messages []Message) (*messageResponse, error) {
+func (c *Client) doRequest(ctx context.Context, system string, messages []Message, params types.SamplingParams) (*messageResponse, error) {
+	maxTokens := params.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = defaultMaxTokens
+		// max_tokens includes the thinking budget, so leave room for the answer
+		if params.ThinkingBudget > 0 {
+			maxTokens += params.ThinkingBudget
+		}
+	}
+
 	reqBody := messageRequest{
-		Model:     c.model,
-		MaxTokens: defaultMaxTokens,
-		System:    system,
-		Messages:  messages,
+		Model:         c.model,
+		MaxTokens:     maxTokens,
+		System:        system,
+		Messages:      messages,
+		Temperature:   params.Temperature,
+		TopP:          params.TopP,
+		StopSequences: params.StopSequences,
+	}
+	if params.ThinkingBudget > 0 {
+		reqBody.Thinking = &thinking{Type: "enabled", BudgetTokens: params.ThinkingBudget}
 	}
 
 	jsonBody, err := json.Marshal(reqBody)
@@ -169,12 +309,24 @@ func (c *Client) doRequest(ctx context.Context, system string, messages []Messag
 
 // extractText extracts the text content from a message response
 func (c *Client) extractText(resp *messageResponse) string {
+	var sb strings.Builder
 	for _, content := range resp.Content {
 		if content.Type == "text" {
-			return content.Text
+			sb.WriteString(content.Text)
+		}
+	}
+	return sb.String()
+}
+
+// extractThinking joins the extended thinking blocks from a message response
+func (c *Client) extractThinking(resp *messageResponse) string {
+	var blocks []string
+	for _, content := range resp.Content {
+		if content.Type == "thinking" && content.Thinking != "" {
+			blocks = append(blocks, content.Thinking)
 		}
 	}
-	return ""
+	return strings.Join(blocks, "\n\n")
 }
 
 // APIError represents an error from the Anthropic API
@@ -0,0 +1,79 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+// replayServer answers each request with the next of the given texts, stopping
+// at max_tokens on all but the last, and records the requests it got
+func replayServer(t *testing.T, texts []string) (*Client, *[]messageRequest) {
+	t.Helper()
+	var requests []messageRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req messageRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("bad request: %v", err)
+		}
+		requests = append(requests, req)
+
+		i := len(requests) - 1
+		stop := "end_turn"
+		if i < len(texts)-1 {
+			stop = "max_tokens"
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"content":     []map[string]string{{"type": "text", "text": texts[i]}},
+			"stop_reason": stop,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient("key", "model")
+	client.baseURL = server.URL
+	return client, &requests
+}
+
+func TestSendMessageContinuation(t *testing.T) {
+	tests := []struct {
+		name     string
+		texts    []string
+		want     string
+		prefills []string // Assistant prefill sent with each continuation
+	}{
+		{"cut inside a word", []string{"The qui", "ck fox"}, "The quick fox", []string{"The qui"}},
+		{"space put back", []string{"The quick ", "brown fox"}, "The quick brown fox", []string{"The quick"}},
+		{"model's own space kept", []string{"The quick ", " brown fox"}, "The quick brown fox", []string{"The quick"}},
+		{"newlines put back", []string{"Line one\n\n", "Line two"}, "Line one\n\nLine two", []string{"Line one"}},
+		{"several continuations", []string{"a ", "b ", "c"}, "a b c", []string{"a", "a b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, requests := replayServer(t, tt.texts)
+
+			resp, err := client.SendMessage(context.Background(), "", []Message{{Role: "user", Content: []ContentBlock{TextBlock("go")}}}, types.SamplingParams{})
+			if err != nil {
+				t.Fatalf("SendMessage: %v", err)
+			}
+			if resp.Text != tt.want {
+				t.Errorf("text = %q, want %q", resp.Text, tt.want)
+			}
+			if resp.Continuations != len(tt.prefills) || resp.Truncated {
+				t.Errorf("continuations = %d, truncated = %v; want %d, false", resp.Continuations, resp.Truncated, len(tt.prefills))
+			}
+			for i, want := range tt.prefills {
+				msgs := (*requests)[i+1].Messages
+				last := msgs[len(msgs)-1]
+				if last.Role != "assistant" || last.Content[0].Text != want {
+					t.Errorf("prefill %d = %s %q, want assistant %q", i+1, last.Role, last.Content[0].Text, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package agent
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+// builtinPersonas are the personas available by name without any extra configuration
+var builtinPersonas = map[string]types.Persona{
+	"security": {
+		Name:         "Security Reviewer",
+		Instructions: "You are a security reviewer. You care most about input validation, injection, authentication and authorization, secrets handling, and unsafe defaults.",
+		Generate:     "Make your solution secure by default and call out any security assumptions explicitly.",
+		Critique:     "Look for vulnerabilities, unsafe defaults, and missing validation in each solution.",
+		Vote:         "Weigh security weaknesses heavily when ranking.",
+	},
+	"performance": {
+		Name:         "Performance Engineer",
+		Instructions: "You are a performance engineer. You care most about time and space complexity, allocations, I/O, and behaviour under load.",
+		Generate:     "Prefer efficient algorithms and data structures, and state the complexity of your approach.",
+		Critique:     "Identify performance bottlenecks, unnecessary work, and scalability limits in each solution.",
+		Vote:         "Weigh efficiency and scalability heavily when ranking.",
+	},
+	"pragmatist": {
+		Name:         "Pragmatist",
+		Instructions: "You are a pragmatist. You care most about simplicity, maintainability, and shipping something that works.",
+		Generate:     "Choose the simplest approach that fully solves the task. Avoid speculative generality.",
+		Critique:     "Point out over-engineering, unclear code, and anything that would be hard to maintain.",
+		Vote:         "Favour solutions that are correct, simple, and easy to maintain.",
+	},
+	"skeptic": {
+		Name:         "Skeptic",
+		Instructions: "You are a skeptic. You question assumptions and look for the cases where things break.",
+		Generate:     "State your assumptions explicitly and handle edge cases carefully.",
+		Critique:     "Challenge each solution's assumptions and look for edge cases, incorrect claims, and failure modes.",
+		Vote:         "Rank down solutions that rely on unverified assumptions or miss edge cases.",
+	},
+}
+
+// LookupPersona returns the built-in persona with the given name
+func LookupPersona(name string) (types.Persona, bool) {
+	p, ok := builtinPersonas[strings.ToLower(strings.TrimSpace(name))]
+	return p, ok
+}
+
+// ResolvePersonas maps persona names to their definitions, failing on unknown names.
+// Custom personas (keyed by lowercase name) take precedence over the built-ins.
+func ResolvePersonas(names []string, custom map[string]types.Persona) ([]types.Persona, error) {
+	personas := make([]types.Persona, 0, len(names))
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if p, ok := custom[key]; ok {
+			if p.Name == "" {
+				p.Name = name
+			}
+			personas = append(personas, p)
+			continue
+		}
+
+		p, ok := LookupPersona(key)
+		if !ok {
+			available := PersonaNames()
+			for customName := range custom {
+				available = append(available, customName)
+			}
+			sort.Strings(available)
+			return nil, fmt.Errorf("unknown persona %q (available: %s)", name, strings.Join(available, ", "))
+		}
+		personas = append(personas, p)
+	}
+	return personas, nil
+}
+
+// PersonaNames returns the names of all built-in personas, sorted
+func PersonaNames() []string {
+	names := make([]string, 0, len(builtinPersonas))
+	for name := range builtinPersonas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
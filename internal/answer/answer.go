@@ -0,0 +1,79 @@
+// Package answer extracts short final answers from solution text so they can be
+// compared across agents and against reference answers.
+package answer
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	boxedPattern  = regexp.MustCompile(`\\boxed\{([^{}]*)\}`)
+	answerPattern = regexp.MustCompile(`(?i)^[\W_]*(?:final answer|answer)[\W_]*?[:：]\s*(.+)$`)
+	spacePattern  = regexp.MustCompile(`\s+`)
+)
+
+// Extract returns the final answer in a solution: the last \boxed{...}, else
+// the last "Answer: ..." or "Final answer: ..." line, else the last non-empty
+// line outside a code fence.
+func Extract(content string) string {
+	if matches := boxedPattern.FindAllStringSubmatch(content, -1); len(matches) > 0 {
+		return strings.TrimSpace(matches[len(matches)-1][1])
+	}
+
+	lines := strings.Split(content, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if m := answerPattern.FindStringSubmatch(strings.TrimSpace(lines[i])); m != nil {
+			return strings.TrimSpace(m[1])
+		}
+	}
+
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if line != "" && !strings.HasPrefix(line, "```") {
+			return line
+		}
+	}
+	return ""
+}
+
+// Normalize canonicalises an answer for comparison: case, surrounding
+// markdown and quotes, a trailing period, and runs of whitespace are ignored.
+func Normalize(answer string) string {
+	answer = strings.TrimSpace(answer)
+	answer = strings.Trim(answer, "*_`\"' ")
+	answer = strings.TrimSuffix(answer, ".")
+	answer = spacePattern.ReplaceAllString(answer, " ")
+	return strings.ToLower(strings.TrimSpace(answer))
+}
+
+// Majority returns the index of a solution giving the most common normalized
+// answer, or false if no answer is strictly more common than every other.
+func Majority(contents []string) (int, bool) {
+	counts := make(map[string]int)
+	first := make(map[string]int)
+	for i, content := range contents {
+		a := Normalize(Extract(content))
+		if a == "" {
+			continue
+		}
+		if _, ok := first[a]; !ok {
+			first[a] = i
+		}
+		counts[a]++
+	}
+
+	best, bestCount, tied := "", 0, false
+	for a, count := range counts {
+		switch {
+		case count > bestCount:
+			best, bestCount, tied = a, count, false
+		case count == bestCount:
+			tied = true
+		}
+	}
+	if bestCount == 0 || tied {
+		return 0, false
+	}
+	return first[best], true
+}
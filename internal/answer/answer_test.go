@@ -0,0 +1,69 @@
+package answer
+
+import "testing"
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"last boxed", "First \\boxed{1}, then \\boxed{ 42 }.\nAnswer: 7", "42"},
+		{"answer line", "Work...\nAnswer: 391\nThanks", "391"},
+		{"final answer line", "Final Answer: Paris", "Paris"},
+		{"last answer line wins", "Answer: 1\nAnswer: 2", "2"},
+		{"last line", "Some reasoning\n\n1969\n", "1969"},
+		{"skips code fence", "```python\nprint(1)\n```", "print(1)"},
+		{"empty", "  \n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Extract(tt.content); got != tt.want {
+				t.Errorf("Extract() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"  Paris. ", "paris"},
+		{"**391**", "391"},
+		{"`x  =   2`", "x = 2"},
+		{"\"Yes\"", "yes"},
+	}
+
+	for _, tt := range tests {
+		if got := Normalize(tt.in); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMajority(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents []string
+		want     int
+		ok       bool
+	}{
+		{"clear majority", []string{"Answer: 3", "Answer: 4", "Answer: 4.", "\\boxed{4}"}, 1, true},
+		{"tie between answers", []string{"Answer: 3", "Answer: 4", "Answer: 3", "Answer: 4"}, 0, false},
+		{"plurality", []string{"Answer: 1", "Answer: 2", "Answer: 2", "Answer: 3"}, 1, true},
+		{"no answers", []string{"", "  "}, 0, false},
+		{"empty answers ignored", []string{"", "Answer: 5"}, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Majority(tt.contents)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Majority() = (%d, %t), want (%d, %t)", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
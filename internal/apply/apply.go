@@ -0,0 +1,290 @@
+// Package apply turns a solution into changes to a working tree: either the
+// unified diffs it contains, applied with git apply, or the named file blocks
+// it contains, written in place.
+package apply
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/humzahkiani/council/internal/codeblock"
+)
+
+// ErrNothingToApply is returned when a solution has no diff or named file blocks
+var ErrNothingToApply = errors.New("solution contains no unified diff or code blocks with file names")
+
+// File is a whole-file change from a code block that names its file
+type File struct {
+	Path      string // Relative to the root, slash-separated
+	Content   string
+	Exists    bool // The file already exists and will be overwritten
+	Dirty     bool // The file has uncommitted changes in git
+	Untracked bool // The file exists but git doesn't track it, so an overwrite can't be undone
+}
+
+// Plan is the set of changes extracted from a solution
+type Plan struct {
+	Root  string // Directory changes are applied in: the git top level, or the working directory
+	Patch string // Unified diff from diff/patch blocks; empty when applying files
+	Files []File // Whole files; empty when applying a patch
+}
+
+// NewPlan extracts changes from solution content. Diff blocks take precedence;
+// otherwise every code block with a file name becomes a whole-file write.
+func NewPlan(content string) (*Plan, error) {
+	root, err := findRoot()
+	if err != nil {
+		return nil, err
+	}
+	plan := &Plan{Root: root}
+
+	blocks := codeblock.Extract(content)
+
+	var patches []string
+	for _, block := range blocks {
+		if isDiff(block) {
+			patches = append(patches, block.Code)
+		}
+	}
+	if len(patches) > 0 {
+		plan.Patch = strings.Join(patches, "")
+		return plan, nil
+	}
+
+	seen := make(map[string]int)
+	for _, block := range blocks {
+		if block.Filename == "" {
+			continue
+		}
+		file := File{Path: block.Filename, Content: block.Code}
+		if _, err := os.Stat(plan.path(file.Path)); err == nil {
+			file.Exists = true
+		}
+		// A later block for the same file replaces the earlier one
+		if i, ok := seen[file.Path]; ok {
+			plan.Files[i] = file
+			continue
+		}
+		seen[file.Path] = len(plan.Files)
+		plan.Files = append(plan.Files, file)
+	}
+	if len(plan.Files) == 0 {
+		return nil, ErrNothingToApply
+	}
+
+	plan.markUnsafe()
+	return plan, nil
+}
+
+// isDiff reports whether a code block holds a unified diff
+func isDiff(block codeblock.Block) bool {
+	if block.Lang == "diff" || block.Lang == "patch" {
+		return true
+	}
+	code := strings.TrimLeft(block.Code, "\n")
+	return strings.HasPrefix(code, "diff --git ") || (strings.HasPrefix(code, "--- ") && strings.Contains(code, "\n+++ "))
+}
+
+// findRoot returns the git top level if the working directory is in a repo, else the working directory
+func findRoot() (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err == nil {
+		return strings.TrimSpace(string(out)), nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+	return cwd, nil
+}
+
+// path returns the absolute path for a relative file path
+func (p *Plan) path(rel string) string {
+	return filepath.Join(p.Root, filepath.FromSlash(rel))
+}
+
+// markUnsafe flags files an overwrite would lose for good: tracked files with
+// uncommitted changes, and existing files git doesn't track at all (untracked
+// or ignored, or anywhere outside a repo), which have no copy to restore
+func (p *Plan) markUnsafe() {
+	tracked, dirty, ok := p.gitState()
+	for i := range p.Files {
+		f := &p.Files[i]
+		if !f.Exists {
+			continue
+		}
+		f.Dirty = dirty[f.Path]
+		f.Untracked = !ok || !tracked[f.Path]
+	}
+}
+
+// gitState returns which of the plan's files git tracks and which of those
+// have uncommitted changes; ok is false outside a git repo
+func (p *Plan) gitState() (tracked, dirty map[string]bool, ok bool) {
+	var paths []string
+	for _, f := range p.Files {
+		paths = append(paths, f.Path)
+	}
+
+	cmd := exec.Command("git", append([]string{"ls-files", "-z", "--"}, paths...)...)
+	cmd.Dir = p.Root
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, nil, false // Not a git repo; nothing tracks the files
+	}
+	tracked = make(map[string]bool)
+	for _, name := range strings.Split(string(out), "\x00") {
+		if name != "" {
+			tracked[name] = true
+		}
+	}
+
+	cmd = exec.Command("git", append([]string{"status", "--porcelain", "-z", "--untracked-files=no", "--"}, paths...)...)
+	cmd.Dir = p.Root
+	out, err = cmd.Output()
+	if err != nil {
+		return nil, nil, false
+	}
+	dirty = make(map[string]bool)
+	for _, entry := range strings.Split(string(out), "\x00") {
+		if len(entry) > 3 {
+			dirty[entry[3:]] = true
+		}
+	}
+	return tracked, dirty, true
+}
+
+// Conflicts lists the reasons the plan can't be applied cleanly
+func (p *Plan) Conflicts() []string {
+	if p.Patch != "" {
+		out, err := p.git("apply", "--check", "--verbose")
+		if err != nil {
+			return []string{strings.TrimSpace(out)}
+		}
+		return nil
+	}
+
+	var conflicts []string
+	for _, f := range p.Files {
+		switch {
+		case f.Untracked:
+			conflicts = append(conflicts, fmt.Sprintf("%s exists but isn't tracked by git, so overwriting it couldn't be undone", f.Path))
+		case f.Dirty:
+			conflicts = append(conflicts, fmt.Sprintf("%s has uncommitted changes that would be overwritten", f.Path))
+		}
+	}
+	return conflicts
+}
+
+// Preview describes the changes: the diffstat and patch, or a diff of each file against its current contents
+func (p *Plan) Preview() string {
+	var sb strings.Builder
+
+	if p.Patch != "" {
+		if stat, err := p.git("apply", "--stat"); err == nil {
+			sb.WriteString(stat)
+			sb.WriteString("\n")
+		}
+		sb.WriteString(p.Patch)
+		return sb.String()
+	}
+
+	for _, f := range p.Files {
+		action := "create"
+		if f.Exists {
+			action = "overwrite"
+		}
+		switch {
+		case f.Untracked:
+			action += " (not tracked by git)"
+		case f.Dirty:
+			action += " (has uncommitted changes)"
+		}
+		sb.WriteString(fmt.Sprintf("%s %s\n", action, f.Path))
+	}
+	sb.WriteString("\n")
+
+	for _, f := range p.Files {
+		sb.WriteString(p.fileDiff(f))
+	}
+	return sb.String()
+}
+
+// fileDiff diffs a file's current contents against the new contents with git diff --no-index
+func (p *Plan) fileDiff(f File) string {
+	tmp, err := os.CreateTemp("", "council-apply-*")
+	if err != nil {
+		return ""
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.WriteString(f.Content)
+	tmp.Close()
+	if err != nil {
+		return ""
+	}
+
+	old := p.path(f.Path)
+	if !f.Exists {
+		old = os.DevNull
+	}
+	cmd := exec.Command("git", "diff", "--no-index", "--no-color", "--", old, tmp.Name())
+	cmd.Dir = p.Root
+	out, _ := cmd.Output() // Exits 1 when the files differ
+
+	// Show repo-relative names instead of temp paths
+	diff := strings.ReplaceAll(string(out), tmp.Name(), "/"+f.Path)
+	if f.Exists {
+		diff = strings.ReplaceAll(diff, old, "/"+f.Path)
+	}
+	return diff
+}
+
+// Apply makes the changes. The patch is applied with git apply; files are written in place.
+func (p *Plan) Apply() error {
+	if p.Patch != "" {
+		if out, err := p.git("apply"); err != nil {
+			return fmt.Errorf("git apply failed: %s", strings.TrimSpace(out))
+		}
+		return nil
+	}
+
+	for _, f := range p.Files {
+		path := p.path(f.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", f.Path, err)
+		}
+		mode := os.FileMode(0644)
+		if info, err := os.Stat(path); err == nil {
+			mode = info.Mode().Perm()
+		}
+		if err := os.WriteFile(path, []byte(f.Content), mode); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.Path, err)
+		}
+	}
+	return nil
+}
+
+// Summary describes what Apply changes in one line
+func (p *Plan) Summary() string {
+	if p.Patch != "" {
+		return "patch"
+	}
+	return fmt.Sprintf("%d file(s)", len(p.Files))
+}
+
+// git runs a git command in the root with the patch on stdin, returning combined output
+func (p *Plan) git(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = p.Root
+	cmd.Stdin = strings.NewReader(p.Patch)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	return out.String(), err
+}
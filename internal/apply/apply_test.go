@@ -0,0 +1,116 @@
+package apply
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// gitRepo creates a repo with tracked.txt and dirty.txt committed, then
+// modifies dirty.txt and adds untracked.txt and an ignored ignored.log
+func gitRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run("init", "-q")
+	write("tracked.txt", "tracked\n")
+	write("dirty.txt", "dirty\n")
+	write(".gitignore", "*.log\n")
+	run("add", ".")
+	run("commit", "-q", "-m", "init")
+
+	write("dirty.txt", "edited\n")
+	write("untracked.txt", "keep me\n")
+	write("ignored.log", "keep me too\n")
+	return dir
+}
+
+func TestNewPlanFlagsUnsafeFiles(t *testing.T) {
+	t.Chdir(gitRepo(t))
+
+	tests := []struct {
+		path      string
+		exists    bool
+		dirty     bool
+		untracked bool
+		conflict  string
+	}{
+		{path: "new.txt"},
+		{path: "tracked.txt", exists: true},
+		{path: "dirty.txt", exists: true, dirty: true, conflict: "uncommitted changes"},
+		{path: "untracked.txt", exists: true, untracked: true, conflict: "isn't tracked by git"},
+		{path: "ignored.log", exists: true, untracked: true, conflict: "isn't tracked by git"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			plan, err := NewPlan("```text " + tt.path + "\nreplaced\n```\n")
+			if err != nil {
+				t.Fatalf("NewPlan: %v", err)
+			}
+			if len(plan.Files) != 1 {
+				t.Fatalf("got %d files, want 1", len(plan.Files))
+			}
+
+			f := plan.Files[0]
+			if f.Exists != tt.exists || f.Dirty != tt.dirty || f.Untracked != tt.untracked {
+				t.Errorf("got exists=%v dirty=%v untracked=%v, want %v %v %v",
+					f.Exists, f.Dirty, f.Untracked, tt.exists, tt.dirty, tt.untracked)
+			}
+
+			conflicts := plan.Conflicts()
+			if tt.conflict == "" {
+				if len(conflicts) != 0 {
+					t.Errorf("unexpected conflicts: %v", conflicts)
+				}
+				return
+			}
+			if len(conflicts) != 1 || !strings.Contains(conflicts[0], tt.conflict) {
+				t.Errorf("conflicts = %v, want one mentioning %q", conflicts, tt.conflict)
+			}
+		})
+	}
+}
+
+func TestNewPlanOutsideRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+	t.Chdir(dir)
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("mine\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := NewPlan("```text notes.txt\nreplaced\n```\n```text new.txt\nnew\n```\n")
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+	conflicts := plan.Conflicts()
+	if len(conflicts) != 1 || !strings.HasPrefix(conflicts[0], "notes.txt ") {
+		t.Errorf("conflicts = %v, want only notes.txt", conflicts)
+	}
+}
@@ -0,0 +1,177 @@
+// Package attach loads the files and images attached to a task.
+package attach
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+const (
+	DefaultMaxFileBytes  = 100 * 1024
+	DefaultMaxTotalBytes = 500 * 1024
+)
+
+// File is a context file loaded into memory
+type File struct {
+	types.ContextFile
+	Content string
+}
+
+// LoadFiles reads every file matched by the given paths, which may be files,
+// directories (walked recursively, skipping hidden entries) or glob patterns.
+// Zero limits use the defaults. Exceeding a limit or matching nothing is an error.
+func LoadFiles(paths []string, maxFileBytes, maxTotalBytes int64) ([]File, error) {
+	if maxFileBytes <= 0 {
+		maxFileBytes = DefaultMaxFileBytes
+	}
+	if maxTotalBytes <= 0 {
+		maxTotalBytes = DefaultMaxTotalBytes
+	}
+
+	var matched []string
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		files, err := expand(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no files match %s", path)
+		}
+		for _, f := range files {
+			if !seen[f] {
+				seen[f] = true
+				matched = append(matched, f)
+			}
+		}
+	}
+	sort.Strings(matched)
+
+	var loaded []File
+	var total int64
+	for _, path := range matched {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat context file: %w", err)
+		}
+		if info.Size() > maxFileBytes {
+			return nil, fmt.Errorf("context file %s is %d bytes, over the %d byte limit", path, info.Size(), maxFileBytes)
+		}
+		total += info.Size()
+		if total > maxTotalBytes {
+			return nil, fmt.Errorf("context files exceed the %d byte total limit at %s", maxTotalBytes, path)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read context file: %w", err)
+		}
+		if isBinary(data) {
+			return nil, fmt.Errorf("context file %s appears to be binary", path)
+		}
+
+		sum := sha256.Sum256(data)
+		loaded = append(loaded, File{
+			ContextFile: types.ContextFile{
+				Path:   filepath.ToSlash(path),
+				Size:   int64(len(data)),
+				SHA256: hex.EncodeToString(sum[:]),
+			},
+			Content: string(data),
+		})
+	}
+
+	return loaded, nil
+}
+
+// expand resolves a file, directory or glob into the regular files it names
+func expand(path string) ([]string, error) {
+	candidates := []string{path}
+	if strings.ContainsAny(path, "*?[") {
+		matches, err := filepath.Glob(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid context pattern %s: %w", path, err)
+		}
+		candidates = matches
+	}
+
+	var files []string
+	for _, candidate := range candidates {
+		info, err := os.Stat(candidate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read context path: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, filepath.Clean(candidate))
+			continue
+		}
+
+		err = filepath.WalkDir(candidate, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if p != candidate && strings.HasPrefix(d.Name(), ".") {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.Type().IsRegular() {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk context directory: %w", err)
+		}
+	}
+
+	return files, nil
+}
+
+// isBinary reports whether data looks like a binary file (contains a NUL byte near the start)
+func isBinary(data []byte) bool {
+	if len(data) > 8000 {
+		data = data[:8000]
+	}
+	return bytes.IndexByte(data, 0) != -1
+}
+
+// Pack formats files into a clearly delimited context section for prompts
+func Pack(files []File) string {
+	if len(files) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("The following files are attached as context for the task.\n\n")
+	sb.WriteString("<context>\n")
+	for _, f := range files {
+		sb.WriteString(fmt.Sprintf("<file path=%q sha256=%q>\n", f.Path, f.SHA256))
+		sb.WriteString(f.Content)
+		if !strings.HasSuffix(f.Content, "\n") {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("</file>\n")
+	}
+	sb.WriteString("</context>")
+	return sb.String()
+}
+
+// Records returns the session records for the loaded files
+func Records(files []File) []types.ContextFile {
+	records := make([]types.ContextFile, len(files))
+	for i, f := range files {
+		records[i] = f.ContextFile
+	}
+	return records
+}
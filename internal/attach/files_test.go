@@ -0,0 +1,111 @@
+package attach
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		t.Helper()
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	write("src/a.go", "package a\n")
+	write("src/b.go", "package b\n")
+	write("src/.hidden", "secret\n")
+	write("src/.git/config", "[core]\n")
+	write("big.txt", strings.Repeat("x", 200))
+	write("bin.dat", "ELF\x00\x01")
+	write("notes.md", "# Notes\n")
+
+	rel := func(names ...string) []string {
+		var paths []string
+		for _, name := range names {
+			paths = append(paths, filepath.ToSlash(filepath.Join(dir, name)))
+		}
+		return paths
+	}
+
+	tests := []struct {
+		name     string
+		paths    []string
+		maxFile  int64
+		maxTotal int64
+		want     []string // Loaded paths, in order
+		wantErr  string
+	}{
+		{name: "single file", paths: []string{"notes.md"}, want: rel("notes.md")},
+		{name: "directory skips hidden entries", paths: []string{"src"}, want: rel("src/a.go", "src/b.go")},
+		{name: "glob", paths: []string{"src/*.go"}, want: rel("src/a.go", "src/b.go")},
+		{name: "duplicates loaded once", paths: []string{"src/a.go", "src", "src/*.go"}, want: rel("src/a.go", "src/b.go")},
+		{name: "file at the limit", paths: []string{"big.txt"}, maxFile: 200, want: rel("big.txt")},
+		{name: "file over the limit", paths: []string{"big.txt"}, maxFile: 199, wantErr: "over the 199 byte limit"},
+		{name: "total over the limit", paths: []string{"src"}, maxTotal: 15, wantErr: "exceed the 15 byte total limit"},
+		{name: "binary rejected", paths: []string{"bin.dat"}, wantErr: "appears to be binary"},
+		{name: "glob matching nothing", paths: []string{"*.rs"}, wantErr: "no files match"},
+		{name: "missing file", paths: []string{"gone.txt"}, wantErr: "failed to read context path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths := make([]string, len(tt.paths))
+			for i, p := range tt.paths {
+				paths[i] = filepath.Join(dir, p)
+			}
+
+			files, err := LoadFiles(paths, tt.maxFile, tt.maxTotal)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadFiles error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadFiles: %v", err)
+			}
+
+			var got []string
+			for _, f := range files {
+				got = append(got, f.Path)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("paths = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFilesRecordsContent(t *testing.T) {
+	content := "line one\nline two\n"
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := LoadFiles([]string{path}, 0, 0)
+	if err != nil {
+		t.Fatalf("LoadFiles: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("got %d files, want 1", len(files))
+	}
+
+	sum := sha256.Sum256([]byte(content))
+	f := files[0]
+	if f.SHA256 != hex.EncodeToString(sum[:]) || f.Size != int64(len(content)) || f.Content != content {
+		t.Errorf("file = %+v, want size %d and sha256 %x", f, len(content), sum)
+	}
+}
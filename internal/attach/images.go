@@ -0,0 +1,68 @@
+package attach
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+// MaxImageBytes is the largest image the API accepts
+const MaxImageBytes = 5 * 1024 * 1024
+
+// supportedImageTypes are the image media types that can be attached
+var supportedImageTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+}
+
+// Image is an image attachment loaded into memory
+type Image struct {
+	types.ImageAttachment
+	Data string // Base64-encoded file contents
+}
+
+// LoadImages reads PNG and JPEG files, detecting the media type from their contents
+func LoadImages(paths []string) ([]Image, error) {
+	var images []Image
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read image: %w", err)
+		}
+		if len(data) > MaxImageBytes {
+			return nil, fmt.Errorf("image %s is %d bytes, over the %d byte limit", path, len(data), MaxImageBytes)
+		}
+
+		mediaType := http.DetectContentType(data)
+		if !supportedImageTypes[mediaType] {
+			return nil, fmt.Errorf("image %s has unsupported type %s (PNG and JPEG are supported)", path, mediaType)
+		}
+
+		sum := sha256.Sum256(data)
+		images = append(images, Image{
+			ImageAttachment: types.ImageAttachment{
+				Path:      filepath.ToSlash(filepath.Clean(path)),
+				MediaType: mediaType,
+				Size:      int64(len(data)),
+				SHA256:    hex.EncodeToString(sum[:]),
+			},
+			Data: base64.StdEncoding.EncodeToString(data),
+		})
+	}
+	return images, nil
+}
+
+// ImageRecords returns the session records for the loaded images
+func ImageRecords(images []Image) []types.ImageAttachment {
+	records := make([]types.ImageAttachment, len(images))
+	for i, img := range images {
+		records[i] = img.ImageAttachment
+	}
+	return records
+}
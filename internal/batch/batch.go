@@ -0,0 +1,293 @@
+// Package batch runs a council for each task in a JSONL file, several at a time.
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/humzahkiani/council/internal/council"
+	"github.com/humzahkiani/council/internal/types"
+)
+
+// Task is one line of a batch file: a task plus optional overrides of the run flags
+type Task struct {
+	ID          string                               `json:"id"` // Defaults to the line number
+	Task        string                               `json:"task"`
+	Agents      *int                                 `json:"agents,omitempty"`
+	Rounds      *int                                 `json:"rounds,omitempty"`
+	Model       *string                              `json:"model,omitempty"`
+	Personas    []string                             `json:"personas,omitempty"`
+	Voting      *string                              `json:"voting,omitempty"`
+	Sampling    map[types.Phase]types.SamplingParams `json:"sampling,omitempty"` // Merged over the flag values
+	Context     []string                             `json:"context,omitempty"`
+	Images      []string                             `json:"images,omitempty"`
+	TokenBudget *int                                 `json:"token_budget,omitempty"`
+}
+
+// Job is a task with its fully resolved council configuration
+type Job struct {
+	ID     string
+	Config *types.Config
+}
+
+// Status summarises how a job ended
+type Status string
+
+const (
+	StatusOK      Status = "ok"
+	StatusTie     Status = "tie"
+	StatusStopped Status = "stopped" // Ended early, e.g. by the token budget
+	StatusFailed  Status = "failed"
+)
+
+// Result is the outcome of one job
+type Result struct {
+	ID       string
+	Session  *types.Session // Nil if the council couldn't be created
+	Path     string         // Where the session was saved
+	Err      error
+	Duration time.Duration
+}
+
+// Status reports how the job ended
+func (r Result) Status() Status {
+	switch {
+	case r.Err != nil:
+		return StatusFailed
+	case r.Session.Stop != nil:
+		return StatusStopped
+	case r.Session.IsTie:
+		return StatusTie
+	default:
+		return StatusOK
+	}
+}
+
+// NewID returns a batch ID that sorts by start time
+func NewID() string {
+	return time.Now().Format("2006-01-02_150405") + "_" + uuid.New().String()[:6]
+}
+
+// Load reads a JSONL batch file. Blank lines are skipped, tasks without an ID
+// are numbered by line, and IDs must be unique.
+func Load(path string) ([]Task, error) {
+	var tasks []*Task
+	err := ReadJSONL(path, func(line []byte) (*Task, error) {
+		task := &Task{}
+		if err := DecodeStrict(line, task); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+		return task, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Task, len(tasks))
+	for i, task := range tasks {
+		result[i] = *task
+	}
+	return result, nil
+}
+
+// ReadJSONL calls decode for each non-blank line of a JSONL task file. decode
+// returns the line's Task, which may be embedded in a larger item, so that its
+// text can be checked and a default ID assigned in place.
+func ReadJSONL(path string, decode func(line []byte) (*Task, error)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	seen := make(map[string]int)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		task, err := decode(line)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", lineNum, err)
+		}
+
+		task.Task = strings.TrimSpace(task.Task)
+		if task.Task == "" {
+			return fmt.Errorf("line %d: task is empty", lineNum)
+		}
+		if task.ID == "" {
+			task.ID = strconv.Itoa(lineNum)
+		}
+		if prev, ok := seen[task.ID]; ok {
+			return fmt.Errorf("line %d: duplicate id %q (first used on line %d)", lineNum, task.ID, prev)
+		}
+		seen[task.ID] = lineNum
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if len(seen) == 0 {
+		return fmt.Errorf("%s contains no tasks", path)
+	}
+
+	return nil
+}
+
+// DecodeStrict decodes one JSON line into v, rejecting unknown fields
+func DecodeStrict(line []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+// Run runs every job with at most parallel councils at once, saving each
+// session tagged with the batch ID. A line is written to progress as each job
+// finishes. Results are returned in job order.
+func Run(ctx context.Context, batchID string, jobs []Job, parallel int, progress io.Writer) []Result {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	results := make([]Result, len(jobs))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	done := 0
+
+	for i, job := range jobs {
+		wg.Add(1)
+		go func(i int, job Job) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				results[i] = Result{ID: job.ID, Err: ctx.Err()}
+				return
+			}
+
+			result := runJob(ctx, batchID, job)
+			results[i] = result
+
+			mu.Lock()
+			defer mu.Unlock()
+			done++
+			fmt.Fprintf(progress, "[%d/%d] %s: %s\n", done, len(jobs), job.ID, describe(result))
+		}(i, job)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// runJob runs a single council, always saving its session
+func runJob(ctx context.Context, batchID string, job Job) Result {
+	start := time.Now()
+	result := Result{ID: job.ID}
+
+	cfg := *job.Config
+	cfg.Save = true
+	cfg.OutputPath = ""
+	cfg.Verbose = false
+
+	c, err := council.New(&cfg)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	c.SetProgress(io.Discard)
+
+	session := c.Session()
+	session.BatchID = batchID
+	session.BatchTaskID = job.ID
+
+	if err := c.Run(ctx); err != nil && !errors.Is(err, council.ErrBudgetExceeded) {
+		result.Err = err
+	}
+	result.Session = session
+	result.Path = c.SavedPath()
+	result.Duration = time.Since(start)
+	return result
+}
+
+// describe is the one-line progress description of a result
+func describe(r Result) string {
+	if r.Err != nil {
+		return fmt.Sprintf("failed: %v", r.Err)
+	}
+	if r.Session.WinnerID != nil {
+		return fmt.Sprintf("%s, winner %s", r.Status(), r.Session.AgentLabel(*r.Session.WinnerID))
+	}
+	return string(r.Status())
+}
+
+// WriteSummary writes a table of every result followed by totals
+func WriteSummary(w io.Writer, batchID string, results []Result) error {
+	fmt.Fprintf(w, "\nBatch %s\n\n", batchID)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSTATUS\tWINNER\tSCORE\tTOKENS\tTIME\tSESSION")
+
+	counts := make(map[Status]int)
+	for _, r := range results {
+		status := r.Status()
+		counts[status]++
+
+		winner, score, tokens, path := "-", "-", "-", "-"
+		if r.Session != nil {
+			if r.Session.WinnerID != nil {
+				winner = r.Session.AgentLabel(*r.Session.WinnerID)
+				for _, st := range r.Session.Standings() {
+					if st.AgentID == *r.Session.WinnerID {
+						score = st.Label
+					}
+				}
+			} else if r.Session.IsTie {
+				winner = fmt.Sprintf("tie %v", r.Session.TiedAgents)
+			}
+			tokens = strconv.Itoa(r.Session.Usage.Total())
+		}
+		if r.Path != "" {
+			path = r.Path
+		}
+		if r.Err != nil {
+			path = shorten(r.Err.Error(), 80) // The full error was already printed as progress
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", r.ID, status, winner, score, tokens, r.Duration.Round(time.Second), path)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d task(s): %d ok, %d tie, %d stopped, %d failed\n",
+		len(results), counts[StatusOK], counts[StatusTie], counts[StatusStopped], counts[StatusFailed])
+	return err
+}
+
+// shorten truncates s to at most n bytes
+func shorten(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
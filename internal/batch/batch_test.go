@@ -0,0 +1,110 @@
+package batch
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		ids     []string // Expected task IDs, in order
+		wantErr string
+	}{
+		{
+			name:    "ids default to the line number",
+			content: `{"task": "a"}` + "\n" + `{"id": "named", "task": "b"}` + "\n" + `{"task": "c"}`,
+			ids:     []string{"1", "named", "3"},
+		},
+		{
+			name:    "blank lines skipped but counted",
+			content: "\n" + `{"task": "a"}` + "\n   \n" + `{"task": "b"}` + "\n",
+			ids:     []string{"2", "4"},
+		},
+		{
+			name:    "duplicate ids",
+			content: `{"id": "x", "task": "a"}` + "\n" + `{"id": "x", "task": "b"}`,
+			wantErr: `line 2: duplicate id "x" (first used on line 1)`,
+		},
+		{
+			name:    "explicit id clashing with a line number",
+			content: `{"id": "2", "task": "a"}` + "\n" + `{"task": "b"}`,
+			wantErr: `line 2: duplicate id "2"`,
+		},
+		{
+			name:    "unknown field",
+			content: `{"task": "a", "agent": 5}`,
+			wantErr: `line 1: json: unknown field "agent"`,
+		},
+		{
+			name:    "empty task",
+			content: `{"task": "a"}` + "\n" + `{"id": "b", "task": "  "}`,
+			wantErr: "line 2: task is empty",
+		},
+		{
+			name:    "invalid json",
+			content: `{"task": "a"`,
+			wantErr: "line 1:",
+		},
+		{
+			name:    "no tasks",
+			content: "\n\n",
+			wantErr: "contains no tasks",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tasks.jsonl")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			tasks, err := Load(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+
+			var ids []string
+			for _, task := range tasks {
+				ids = append(ids, task.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.ids) {
+				t.Errorf("ids = %v, want %v", ids, tt.ids)
+			}
+		})
+	}
+}
+
+func TestLoadOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.jsonl")
+	line := `{"id": "api", "task": "  Design an API  ", "agents": 5, "rounds": 2, "sampling": {"generate": {"temperature": 0.9}}}`
+	if err := os.WriteFile(path, []byte(line), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tasks, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	task := tasks[0]
+	if task.Task != "Design an API" {
+		t.Errorf("task = %q, want it trimmed", task.Task)
+	}
+	if task.Agents == nil || *task.Agents != 5 || task.Rounds == nil || *task.Rounds != 2 || task.Model != nil {
+		t.Errorf("overrides = agents %v rounds %v model %v, want 5, 2 and unset", task.Agents, task.Rounds, task.Model)
+	}
+	if temp := task.Sampling["generate"].Temperature; temp == nil || *temp != 0.9 {
+		t.Errorf("generate temperature = %v, want 0.9", temp)
+	}
+}
@@ -0,0 +1,324 @@
+// Package bench scores council runs against reference answers, comparing the
+// council's winner with each individual agent and with the agents' majority answer.
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/humzahkiani/council/internal/answer"
+	"github.com/humzahkiani/council/internal/batch"
+	"github.com/humzahkiani/council/internal/types"
+	"github.com/humzahkiani/council/internal/verify"
+)
+
+// DefaultCheckTimeout limits how long a command checker may run
+const DefaultCheckTimeout = 60 * time.Second
+
+// Item is one line of a dataset: a batch task plus exactly one checker
+type Item struct {
+	batch.Task
+	Expected *string `json:"expected,omitempty"` // Exact match against the extracted answer, after normalization
+	Regex    string  `json:"regex,omitempty"`    // Regular expression matched against the extracted answer
+	Command  string  `json:"command,omitempty"`  // Shell command; gets the solution on stdin and passes with exit code 0
+
+	regex *regexp.Regexp
+}
+
+// Load reads a JSONL dataset, validating every item's checker
+func Load(path string) ([]Item, error) {
+	var items []*Item
+	err := batch.ReadJSONL(path, func(line []byte) (*batch.Task, error) {
+		item := &Item{}
+		if err := batch.DecodeStrict(line, item); err != nil {
+			return nil, err
+		}
+		if err := item.compile(); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		return &item.Task, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Item, len(items))
+	for i, item := range items {
+		result[i] = *item
+	}
+	return result, nil
+}
+
+// compile checks that exactly one checker is set and compiles a regex checker
+func (it *Item) compile() error {
+	set := 0
+	if it.Expected != nil {
+		set++
+	}
+	if it.Regex != "" {
+		set++
+		re, err := regexp.Compile(it.Regex)
+		if err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+		it.regex = re
+	}
+	if it.Command != "" {
+		set++
+	}
+	if set != 1 {
+		return fmt.Errorf("item needs exactly one of expected, regex or command")
+	}
+	return nil
+}
+
+// Checker decides whether solutions to an item are correct
+type Checker struct {
+	Timeout time.Duration // Per command check; 0 uses DefaultCheckTimeout
+	Dir     string        // Dataset directory, given to command checks as $COUNCIL_DATASET_DIR
+}
+
+// Check reports whether a solution is correct for the item
+func (c Checker) Check(ctx context.Context, item Item, content string) (bool, error) {
+	extracted := answer.Extract(content)
+
+	switch {
+	case item.Expected != nil:
+		return answer.Normalize(extracted) == answer.Normalize(*item.Expected), nil
+	case item.regex != nil:
+		return item.regex.MatchString(strings.TrimSpace(extracted)), nil
+	default:
+		return c.runCommand(ctx, item, content, extracted)
+	}
+}
+
+// runCommand runs a command checker with the solution on stdin. The solution
+// was written by a model, so the command runs in an empty temporary directory
+// with the same stripped environment as --verify-cmd.
+func (c Checker) runCommand(ctx context.Context, item Item, content, extracted string) (bool, error) {
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = DefaultCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	dir, err := os.MkdirTemp("", "council-check-*")
+	if err != nil {
+		return false, fmt.Errorf("failed to create check directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", item.Command)
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(content)
+	cmd.Env = append(verify.Environ(dir),
+		"COUNCIL_ANSWER="+extracted,
+		"COUNCIL_TASK_ID="+item.ID,
+		"COUNCIL_DATASET_DIR="+c.Dir,
+	)
+
+	err = cmd.Run()
+	if err == nil {
+		return true, nil
+	}
+	if ctx.Err() != nil {
+		return false, fmt.Errorf("check command timed out after %s", timeout)
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to run check command: %w", err)
+}
+
+// ItemScore is the outcome of one dataset item
+type ItemScore struct {
+	ID       string
+	Status   batch.Status
+	Winner   bool         // The council's winner was correct; false on a tie
+	Majority bool         // The most common answer among the agents was correct
+	Agents   map[int]bool // AgentID -> that agent's own solution was correct
+	Errors   []string     // Run failure or checker errors
+	Session  *types.Session
+}
+
+// Report aggregates item scores
+type Report struct {
+	Items  []ItemScore
+	Labels map[int]string // AgentID -> display label, from the first session that has one
+}
+
+// Score checks every session's solutions against its item
+func Score(ctx context.Context, checker Checker, items []Item, results []batch.Result) *Report {
+	report := &Report{Labels: make(map[int]string)}
+
+	for i, result := range results {
+		item := items[i]
+		score := ItemScore{ID: item.ID, Status: result.Status(), Agents: make(map[int]bool), Session: result.Session}
+		if result.Err != nil {
+			score.Errors = append(score.Errors, result.Err.Error())
+			report.Items = append(report.Items, score)
+			continue
+		}
+
+		// Grades are recorded on the session, where --weights accuracy learns
+		// from them; a solution whose check errored is left ungraded
+		session := result.Session
+		session.Grades = make(map[int]bool)
+		contents := make([]string, len(session.Solutions))
+		for j, sol := range session.Solutions {
+			contents[j] = sol.Content
+			correct, err := checker.Check(ctx, item, sol.Content)
+			if err != nil {
+				score.Errors = append(score.Errors, err.Error())
+			} else {
+				session.Grades[sol.AgentID] = correct
+			}
+			score.Agents[sol.AgentID] = correct
+			if session.WinnerID != nil && *session.WinnerID == sol.AgentID {
+				score.Winner = correct
+			}
+			if _, ok := report.Labels[sol.AgentID]; !ok {
+				report.Labels[sol.AgentID] = session.AgentLabel(sol.AgentID)
+			}
+		}
+
+		if j, ok := answer.Majority(contents); ok {
+			score.Majority = score.Agents[session.Solutions[j].AgentID]
+		}
+
+		report.Items = append(report.Items, score)
+	}
+
+	return report
+}
+
+// scored returns the items whose council ran, which are the ones counted in accuracy
+func (r *Report) scored() []ItemScore {
+	var items []ItemScore
+	for _, item := range r.Items {
+		if item.Status != batch.StatusFailed {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Write prints a per-item table followed by accuracy for the winner, each agent and the majority
+func (r *Report) Write(w io.Writer) error {
+	agentIDs := make([]int, 0, len(r.Labels))
+	for id := range r.Labels {
+		agentIDs = append(agentIDs, id)
+	}
+	sort.Ints(agentIDs)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := "ID\tSTATUS\tWINNER\tMAJORITY"
+	for _, id := range agentIDs {
+		header += fmt.Sprintf("\tA%d", id)
+	}
+	fmt.Fprintln(tw, header)
+
+	for _, item := range r.Items {
+		ran := item.Status != batch.StatusFailed
+		row := fmt.Sprintf("%s\t%s\t%s\t%s", item.ID, item.Status, mark(item.Winner, ran), mark(item.Majority, ran))
+		for _, id := range agentIDs {
+			correct, ok := item.Agents[id]
+			row += "\t" + mark(correct, ok)
+		}
+		fmt.Fprintln(tw, row)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, item := range r.Items {
+		for _, e := range item.Errors {
+			fmt.Fprintf(w, "%s: %s\n", item.ID, e)
+		}
+	}
+
+	scored := r.scored()
+	fmt.Fprintf(w, "\nAccuracy over %d item(s)", len(scored))
+	if failed := len(r.Items) - len(scored); failed > 0 {
+		fmt.Fprintf(w, " (%d failed run(s) excluded)", failed)
+	}
+	fmt.Fprintln(w)
+
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	winner, majority := 0, 0
+	for _, item := range scored {
+		if item.Winner {
+			winner++
+		}
+		if item.Majority {
+			majority++
+		}
+	}
+	fmt.Fprintf(tw, "Council winner\t%s\n", ratio(winner, len(scored)))
+	fmt.Fprintf(tw, "Majority answer\t%s\n", ratio(majority, len(scored)))
+
+	correctSum, totalSum := 0, 0
+	for _, id := range agentIDs {
+		correct, total := 0, 0
+		for _, item := range scored {
+			if ok, present := item.Agents[id]; present {
+				total++
+				if ok {
+					correct++
+				}
+			}
+		}
+		correctSum += correct
+		totalSum += total
+		fmt.Fprintf(tw, "%s\t%s\n", r.Labels[id], ratio(correct, total))
+	}
+	fmt.Fprintf(tw, "Individual agents (mean)\t%s\n", ratio(correctSum, totalSum))
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	// Token usage, so the cost of a mode can be compared with its accuracy
+	var usage types.Usage
+	for _, item := range scored {
+		if item.Session != nil {
+			usage.InputTokens += item.Session.Usage.InputTokens
+			usage.OutputTokens += item.Session.Usage.OutputTokens
+		}
+	}
+	if len(scored) > 0 {
+		fmt.Fprintf(w, "\nTokens: %d in, %d out (%d per item)\n", usage.InputTokens, usage.OutputTokens, usage.Total()/len(scored))
+	}
+	return nil
+}
+
+// mark renders a correctness cell
+func mark(correct, present bool) string {
+	switch {
+	case !present:
+		return "-"
+	case correct:
+		return "✓"
+	default:
+		return "✗"
+	}
+}
+
+// ratio renders correct/total as a count and percentage
+func ratio(correct, total int) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d (%.1f%%)", correct, total, 100*float64(correct)/float64(total))
+}
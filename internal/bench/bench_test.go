@@ -0,0 +1,129 @@
+package bench
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/humzahkiani/council/internal/batch"
+	"github.com/humzahkiani/council/internal/types"
+)
+
+func TestCheck(t *testing.T) {
+	expected := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		item    Item
+		content string
+		want    bool
+	}{
+		{"expected match", Item{Expected: expected("391")}, "17 * 23\nAnswer: 391.", true},
+		{"expected ignores case and markdown", Item{Expected: expected("Paris")}, "Final answer: **paris**", true},
+		{"expected mismatch", Item{Expected: expected("391")}, "Answer: 390", false},
+		{"regex match", Item{Regex: `^(July )?1969$`}, "It landed in\nJuly 1969", true},
+		{"regex mismatch", Item{Regex: `^1969$`}, "Answer: 1970", false},
+		{"command pass", Item{Command: `test "$COUNCIL_ANSWER" = 42`}, "Answer: 42", true},
+		{"command reads stdin", Item{Command: `grep -q fizz`}, "print('fizz')", true},
+		{"command fail", Item{Command: "exit 1"}, "anything", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := tt.item
+			if err := item.compile(); err != nil {
+				t.Fatalf("compile() error: %v", err)
+			}
+			got, err := Checker{}.Check(context.Background(), item, tt.content)
+			if err != nil {
+				t.Fatalf("Check() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Check() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckCommandSandbox(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "secret")
+	dataset := t.TempDir()
+
+	tests := []struct {
+		name    string
+		command string
+	}{
+		{"no API key", `test -z "$ANTHROPIC_API_KEY"`},
+		{"runs outside the dataset", `test "$(pwd -P)" != "$(cd "$COUNCIL_DATASET_DIR" && pwd -P)"`},
+		{"home is the check directory", `test "$HOME" = "$PWD"`},
+		{"starts empty", `test -z "$(ls -A)"`},
+		{"dataset directory given", `test "$COUNCIL_DATASET_DIR" = "` + dataset + `"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := Item{Command: tt.command}
+			if err := item.compile(); err != nil {
+				t.Fatal(err)
+			}
+			ok, err := Checker{Dir: dataset}.Check(context.Background(), item, "Answer: 1")
+			if err != nil || !ok {
+				t.Errorf("Check() = %t, %v; want the command to pass", ok, err)
+			}
+		})
+	}
+}
+
+func TestCompileNeedsOneChecker(t *testing.T) {
+	expected := "1"
+	tests := []struct {
+		name string
+		item Item
+	}{
+		{"none", Item{}},
+		{"two", Item{Expected: &expected, Regex: "1"}},
+		{"bad regex", Item{Regex: "("}},
+	}
+
+	for _, tt := range tests {
+		if err := tt.item.compile(); err == nil {
+			t.Errorf("%s: compile() succeeded, want an error", tt.name)
+		}
+	}
+}
+
+func TestScoreRecordsGrades(t *testing.T) {
+	winner := 2
+	session := &types.Session{
+		Solutions: []types.Solution{
+			{AgentID: 1, Content: "Answer: 390"},
+			{AgentID: 2, Content: "Answer: 391"},
+			{AgentID: 3, Content: "Answer: 391"},
+		},
+		WinnerID: &winner,
+	}
+	expected := "391"
+	item := Item{Task: batch.Task{ID: "sum"}, Expected: &expected}
+
+	report := Score(context.Background(), Checker{}, []Item{item}, []batch.Result{{ID: "sum", Session: session}})
+
+	want := map[int]bool{1: false, 2: true, 3: true}
+	if !reflect.DeepEqual(session.Grades, want) {
+		t.Errorf("grades = %v, want %v", session.Grades, want)
+	}
+	if got := report.Items[0]; !got.Winner || !got.Majority {
+		t.Errorf("winner correct = %t, majority correct = %t, want both", got.Winner, got.Majority)
+	}
+}
+
+func TestScoreLeavesErroredChecksUngraded(t *testing.T) {
+	session := &types.Session{Solutions: []types.Solution{{AgentID: 1, Content: "Answer: 1"}}}
+	item := Item{Task: batch.Task{ID: "slow"}, Command: "sleep 5"}
+
+	Score(context.Background(), Checker{Timeout: 50 * time.Millisecond}, []Item{item}, []batch.Result{{ID: "slow", Session: session}})
+
+	if _, ok := session.Grades[1]; ok {
+		t.Errorf("grades = %v, want the timed-out check left ungraded", session.Grades)
+	}
+}
@@ -0,0 +1,162 @@
+// Package codeblock extracts fenced code blocks from markdown.
+package codeblock
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Block is one fenced code block
+type Block struct {
+	Lang     string // First word of the info string, lowercased
+	Filename string // From the info string or a leading "file:" comment; empty if not given
+	Code     string
+}
+
+var (
+	// filenameAttr matches title="x", file=x and filename=x in an info string
+	filenameAttr = regexp.MustCompile(`(?:title|file|filename)=["']?([^"'\s]+)`)
+	// filenameComment matches a first line like "// file: main.go" or "# filename: app.py"
+	filenameComment = regexp.MustCompile(`^\s*(?://|#|--|/\*|<!--)\s*(?:file|filename)\s*:\s*(\S+?)\s*(?:\*/|-->)?\s*$`)
+)
+
+// extensions maps common fence languages to file extensions
+var extensions = map[string]string{
+	"go":         ".go",
+	"python":     ".py",
+	"py":         ".py",
+	"javascript": ".js",
+	"js":         ".js",
+	"typescript": ".ts",
+	"ts":         ".ts",
+	"rust":       ".rs",
+	"rs":         ".rs",
+	"java":       ".java",
+	"c":          ".c",
+	"cpp":        ".cpp",
+	"c++":        ".cpp",
+	"ruby":       ".rb",
+	"rb":         ".rb",
+	"sh":         ".sh",
+	"bash":       ".sh",
+	"shell":      ".sh",
+	"sql":        ".sql",
+	"json":       ".json",
+	"yaml":       ".yaml",
+	"yml":        ".yaml",
+	"html":       ".html",
+	"css":        ".css",
+}
+
+// Extract returns the fenced code blocks in content, in order. Fences may use
+// backticks or tildes; an unterminated block runs to the end of the content.
+func Extract(content string) []Block {
+	var blocks []Block
+	lines := strings.Split(content, "\n")
+
+	for i := 0; i < len(lines); i++ {
+		fence, info, ok := openFence(lines[i])
+		if !ok {
+			continue
+		}
+
+		var code []string
+		for i++; i < len(lines); i++ {
+			if closesFence(lines[i], fence) {
+				break
+			}
+			code = append(code, lines[i])
+		}
+
+		blocks = append(blocks, newBlock(info, code))
+	}
+
+	return blocks
+}
+
+// openFence reports whether line opens a code block, returning the fence and info string
+func openFence(line string) (string, string, bool) {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return "", "", false // Indented code, not a fence
+	}
+	for _, ch := range []string{"`", "~"} {
+		n := len(trimmed) - len(strings.TrimLeft(trimmed, ch))
+		if n >= 3 {
+			info := strings.TrimSpace(trimmed[n:])
+			if ch == "`" && strings.Contains(info, "`") {
+				return "", "", false // Inline code span, not a fence
+			}
+			return trimmed[:n], info, true
+		}
+	}
+	return "", "", false
+}
+
+// closesFence reports whether line closes a block opened with fence
+func closesFence(line, fence string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == ""
+}
+
+// newBlock builds a block from its info string and lines, finding any filename hint
+func newBlock(info string, code []string) Block {
+	block := Block{}
+
+	fields := strings.Fields(info)
+	if len(fields) > 0 {
+		lang := fields[0]
+		// ```python:main.py
+		if before, after, ok := strings.Cut(lang, ":"); ok && after != "" {
+			lang, block.Filename = before, after
+		}
+		block.Lang = strings.ToLower(lang)
+	}
+
+	if m := filenameAttr.FindStringSubmatch(info); m != nil {
+		block.Filename = m[1]
+	} else if block.Filename == "" {
+		// ```go main.go
+		for _, f := range fields[min(1, len(fields)):] {
+			if !strings.Contains(f, "=") && strings.Contains(f, ".") {
+				block.Filename = f
+				break
+			}
+		}
+	}
+
+	if block.Filename == "" && len(code) > 0 {
+		if m := filenameComment.FindStringSubmatch(code[0]); m != nil {
+			block.Filename = m[1]
+		}
+	}
+
+	block.Filename = cleanFilename(block.Filename)
+	block.Code = strings.Join(code, "\n")
+	if block.Code != "" {
+		block.Code += "\n"
+	}
+	return block
+}
+
+// cleanFilename returns a relative, slash-separated path, or "" if the name
+// would escape the directory it is written to
+func cleanFilename(name string) string {
+	if name == "" {
+		return ""
+	}
+	name = path.Clean(strings.ReplaceAll(name, "\\", "/"))
+	if path.IsAbs(name) || name == "." || name == ".." || strings.HasPrefix(name, "../") {
+		return ""
+	}
+	return name
+}
+
+// Extension returns the file extension for a block's language, ".txt" if unknown
+func (b Block) Extension() string {
+	if ext, ok := extensions[b.Lang]; ok {
+		return ext
+	}
+	return ".txt"
+}
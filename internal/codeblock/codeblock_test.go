@@ -0,0 +1,113 @@
+package codeblock
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Block
+	}{
+		{
+			name:    "no blocks",
+			content: "Just prose with `inline` code.",
+		},
+		{
+			name:    "language only",
+			content: "Here:\n```Python\nprint(1)\n```\nDone.",
+			want:    []Block{{Lang: "python", Code: "print(1)\n"}},
+		},
+		{
+			name:    "filename after language",
+			content: "```go main.go\npackage main\n```",
+			want:    []Block{{Lang: "go", Filename: "main.go", Code: "package main\n"}},
+		},
+		{
+			name:    "filename after colon",
+			content: "```python:app/main.py\npass\n```",
+			want:    []Block{{Lang: "python", Filename: "app/main.py", Code: "pass\n"}},
+		},
+		{
+			name:    "title attribute",
+			content: "```js title=\"index.js\"\nrun()\n```",
+			want:    []Block{{Lang: "js", Filename: "index.js", Code: "run()\n"}},
+		},
+		{
+			name:    "filename comment",
+			content: "```go\n// file: util.go\npackage util\n```",
+			want:    []Block{{Lang: "go", Filename: "util.go", Code: "// file: util.go\npackage util\n"}},
+		},
+		{
+			name:    "escaping filename dropped",
+			content: "```sh ../evil.sh\nrm -rf /\n```",
+			want:    []Block{{Lang: "sh", Code: "rm -rf /\n"}},
+		},
+		{
+			name:    "absolute filename dropped",
+			content: "```sh /etc/passwd\nx\n```",
+			want:    []Block{{Lang: "sh", Code: "x\n"}},
+		},
+		{
+			name:    "tilde fence holds backticks",
+			content: "~~~md\n```\nnested\n```\n~~~",
+			want:    []Block{{Lang: "md", Code: "```\nnested\n```\n"}},
+		},
+		{
+			name:    "longer closing fence",
+			content: "````\na\n``````\n",
+			want:    []Block{{Code: "a\n"}},
+		},
+		{
+			name:    "unterminated block runs to the end",
+			content: "```py\nx = 1\ny = 2",
+			want:    []Block{{Lang: "py", Code: "x = 1\ny = 2\n"}},
+		},
+		{
+			name:    "indented code is not a fence",
+			content: "    ```go\n    x\n    ```",
+		},
+		{
+			name:    "several blocks in order",
+			content: "```go\na\n```\ntext\n```rust\nb\n```",
+			want:    []Block{{Lang: "go", Code: "a\n"}, {Lang: "rust", Code: "b\n"}},
+		},
+		{
+			name:    "empty block",
+			content: "```text\n```",
+			want:    []Block{{Lang: "text"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Extract(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Extract = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtension(t *testing.T) {
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{"go", ".go"},
+		{"python", ".py"},
+		{"py", ".py"},
+		{"c++", ".cpp"},
+		{"yml", ".yaml"},
+		{"bash", ".sh"},
+		{"", ".txt"},
+		{"brainfuck", ".txt"},
+	}
+
+	for _, tt := range tests {
+		if got := (Block{Lang: tt.lang}).Extension(); got != tt.want {
+			t.Errorf("Extension(%q) = %q, want %q", tt.lang, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+// Package config loads run options from ~/.council/config.yaml and a
+// project-local .council.yaml, organised into named profiles.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/humzahkiani/council/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	globalFile = "config.yaml"
+	localFile  = ".council.yaml"
+)
+
+// File is the contents of one configuration file
+type File struct {
+	DefaultProfile string                   `yaml:"default_profile"`
+	Personas       map[string]types.Persona `yaml:"personas"` // Custom personas, usable by name like the built-ins
+	Profiles       map[string]Profile       `yaml:"profiles"`
+}
+
+// Profile is a named set of run options; unset fields keep the CLI defaults
+type Profile struct {
+	Agents    *int                                 `yaml:"agents"`
+	Rounds    *int                                 `yaml:"rounds"`
+	Model     *string                              `yaml:"model"`
+	Personas  []string                             `yaml:"personas"`
+	Voting    *string                              `yaml:"voting"`
+	Moderator *string                              `yaml:"moderator"`
+	Judges    *int                                 `yaml:"judges"`
+	Prompts   *string                              `yaml:"prompts"`
+	Sampling  map[types.Phase]types.SamplingParams `yaml:"sampling"`
+	Save      *bool                                `yaml:"save"`
+	Verbose   *bool                                `yaml:"verbose"`
+	Context   []string                             `yaml:"context"`
+
+	TokenBudget *int    `yaml:"token_budget"`
+	JudgeModel  *string `yaml:"judge_model"`
+	Weights     *string `yaml:"weights"` // Same syntax as --weights
+}
+
+// Resolved is the profile selected for a run, along with custom personas from every file
+type Resolved struct {
+	Name     string // Empty when no profile applies
+	Profile  Profile
+	Personas map[string]types.Persona
+	Sources  []string // Files that were read, lowest precedence first
+}
+
+// Load reads the global and project-local config files and selects a profile.
+// The local file takes precedence field by field. An empty name selects the
+// files' default_profile, then a profile named "default", then no profile.
+func Load(name string) (*Resolved, error) {
+	paths, err := searchPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	merged := File{
+		Personas: make(map[string]types.Persona),
+		Profiles: make(map[string]Profile),
+	}
+	resolved := &Resolved{}
+
+	for _, path := range paths {
+		file, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if file == nil {
+			continue
+		}
+		resolved.Sources = append(resolved.Sources, path)
+
+		if file.DefaultProfile != "" {
+			merged.DefaultProfile = file.DefaultProfile
+		}
+		for key, persona := range file.Personas {
+			merged.Personas[strings.ToLower(key)] = persona
+		}
+		for key, profile := range file.Profiles {
+			merged.Profiles[key] = merged.Profiles[key].merge(profile)
+		}
+	}
+
+	resolved.Personas = merged.Personas
+
+	switch {
+	case name != "":
+		profile, ok := merged.Profiles[name]
+		if !ok {
+			return nil, fmt.Errorf("profile %q not found (available: %s)", name, profileNames(merged.Profiles))
+		}
+		resolved.Name, resolved.Profile = name, profile
+	case merged.DefaultProfile != "":
+		profile, ok := merged.Profiles[merged.DefaultProfile]
+		if !ok {
+			return nil, fmt.Errorf("default_profile %q not found (available: %s)", merged.DefaultProfile, profileNames(merged.Profiles))
+		}
+		resolved.Name, resolved.Profile = merged.DefaultProfile, profile
+	default:
+		if profile, ok := merged.Profiles["default"]; ok {
+			resolved.Name, resolved.Profile = "default", profile
+		}
+	}
+
+	return resolved, nil
+}
+
+// searchPaths returns the config files to read, lowest precedence first
+func searchPaths() ([]string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	paths := []string{filepath.Join(homeDir, ".council", globalFile)}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+	paths = append(paths, filepath.Join(cwd, localFile))
+
+	return paths, nil
+}
+
+// readFile parses one config file, returning nil if it doesn't exist
+func readFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var file File
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil {
+		if errors.Is(err, io.EOF) {
+			return &file, nil // Empty file
+		}
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return &file, nil
+}
+
+// merge returns p with every field set in other overriding it
+func (p Profile) merge(other Profile) Profile {
+	if other.Agents != nil {
+		p.Agents = other.Agents
+	}
+	if other.Rounds != nil {
+		p.Rounds = other.Rounds
+	}
+	if other.Model != nil {
+		p.Model = other.Model
+	}
+	if other.Personas != nil {
+		p.Personas = other.Personas
+	}
+	if other.Voting != nil {
+		p.Voting = other.Voting
+	}
+	if other.Moderator != nil {
+		p.Moderator = other.Moderator
+	}
+	if other.Judges != nil {
+		p.Judges = other.Judges
+	}
+	if other.Prompts != nil {
+		p.Prompts = other.Prompts
+	}
+	if other.Sampling != nil {
+		p.Sampling = MergeSampling(p.Sampling, other.Sampling)
+	}
+	if other.Save != nil {
+		p.Save = other.Save
+	}
+	if other.Verbose != nil {
+		p.Verbose = other.Verbose
+	}
+	if other.Context != nil {
+		p.Context = other.Context
+	}
+	if other.TokenBudget != nil {
+		p.TokenBudget = other.TokenBudget
+	}
+	if other.JudgeModel != nil {
+		p.JudgeModel = other.JudgeModel
+	}
+	if other.Weights != nil {
+		p.Weights = other.Weights
+	}
+	return p
+}
+
+// MergeSampling overlays the fields set in override onto base, phase by phase
+func MergeSampling(base, override map[types.Phase]types.SamplingParams) map[types.Phase]types.SamplingParams {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+
+	merged := make(map[types.Phase]types.SamplingParams)
+	for phase, params := range base {
+		merged[phase] = params
+	}
+	for phase, o := range override {
+		params := merged[phase]
+		if o.Temperature != nil {
+			params.Temperature = o.Temperature
+		}
+		if o.TopP != nil {
+			params.TopP = o.TopP
+		}
+		if o.MaxTokens != 0 {
+			params.MaxTokens = o.MaxTokens
+		}
+		if o.StopSequences != nil {
+			params.StopSequences = o.StopSequences
+		}
+		if o.ThinkingBudget != 0 {
+			params.ThinkingBudget = o.ThinkingBudget
+		}
+		merged[phase] = params
+	}
+	return merged
+}
+
+// profileNames lists the available profiles for error messages
+func profileNames(profiles map[string]Profile) string {
+	if len(profiles) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
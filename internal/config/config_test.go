@@ -0,0 +1,222 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+// writeConfigs points the search at fresh home and project directories and
+// writes the global and project-local files that aren't empty
+func writeConfigs(t *testing.T, global, local string) {
+	t.Helper()
+	home, project := t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+	t.Chdir(project)
+
+	write := func(path, content string) {
+		t.Helper()
+		if content == "" {
+			return
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(home, ".council", globalFile), global)
+	write(filepath.Join(project, localFile), local)
+}
+
+func TestLoad(t *testing.T) {
+	const global = `
+default_profile: fast
+personas:
+  Auditor:
+    name: Auditor
+    instructions: Check everything.
+profiles:
+  fast:
+    agents: 3
+    model: global-model
+    personas: [skeptic]
+  deep:
+    agents: 7
+    rounds: 3
+`
+
+	tests := []struct {
+		name    string
+		global  string
+		local   string
+		profile string
+		check   func(t *testing.T, r *Resolved)
+		wantErr string
+	}{
+		{
+			name: "no files",
+			check: func(t *testing.T, r *Resolved) {
+				if r.Name != "" || len(r.Sources) != 0 {
+					t.Errorf("resolved = %+v, want no profile and no sources", r)
+				}
+			},
+		},
+		{
+			name:   "default profile from the global file",
+			global: global,
+			check: func(t *testing.T, r *Resolved) {
+				if r.Name != "fast" || *r.Profile.Agents != 3 || *r.Profile.Model != "global-model" {
+					t.Errorf("resolved %q = %+v, want fast", r.Name, r.Profile)
+				}
+				if _, ok := r.Personas["auditor"]; !ok {
+					t.Errorf("personas = %v, want auditor keyed in lower case", r.Personas)
+				}
+			},
+		},
+		{
+			name:   "project file over user file, field by field",
+			global: global,
+			local:  "profiles:\n  fast:\n    model: local-model\n",
+			check: func(t *testing.T, r *Resolved) {
+				if *r.Profile.Model != "local-model" || *r.Profile.Agents != 3 || !reflect.DeepEqual(r.Profile.Personas, []string{"skeptic"}) {
+					t.Errorf("profile = %+v, want the local model over the global agents and personas", r.Profile)
+				}
+				if len(r.Sources) != 2 || !strings.HasSuffix(r.Sources[1], localFile) {
+					t.Errorf("sources = %v, want the global file then the local one", r.Sources)
+				}
+			},
+		},
+		{
+			name:   "project default_profile wins",
+			global: global,
+			local:  "default_profile: deep\n",
+			check: func(t *testing.T, r *Resolved) {
+				if r.Name != "deep" || *r.Profile.Rounds != 3 {
+					t.Errorf("resolved %q = %+v, want deep", r.Name, r.Profile)
+				}
+			},
+		},
+		{
+			name:    "named profile over default_profile",
+			global:  global,
+			profile: "deep",
+			check: func(t *testing.T, r *Resolved) {
+				if r.Name != "deep" || *r.Profile.Agents != 7 {
+					t.Errorf("resolved %q = %+v, want deep", r.Name, r.Profile)
+				}
+			},
+		},
+		{
+			name:  "profile named default",
+			local: "profiles:\n  default:\n    rounds: 2\n",
+			check: func(t *testing.T, r *Resolved) {
+				if r.Name != "default" || *r.Profile.Rounds != 2 {
+					t.Errorf("resolved %q = %+v, want default", r.Name, r.Profile)
+				}
+			},
+		},
+		{name: "missing profile", global: global, profile: "nope", wantErr: `profile "nope" not found (available: deep, fast)`},
+		{name: "missing default_profile", local: "default_profile: gone\n", wantErr: `default_profile "gone" not found`},
+		{name: "unknown field", local: "profiles:\n  x:\n    agent: 3\n", wantErr: "field agent not found"},
+		{name: "empty file", local: "\n", check: func(t *testing.T, r *Resolved) {
+			if len(r.Sources) != 1 {
+				t.Errorf("sources = %v, want the empty file read", r.Sources)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeConfigs(t, tt.global, tt.local)
+
+			resolved, err := Load(tt.profile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			tt.check(t, resolved)
+		})
+	}
+}
+
+func TestLoadMergesSamplingPerPhase(t *testing.T) {
+	writeConfigs(t,
+		"profiles:\n  default:\n    sampling:\n      generate: {temperature: 1.0, max_tokens: 8192}\n      vote: {temperature: 0.2}\n",
+		"profiles:\n  default:\n    sampling:\n      generate: {temperature: 0.7}\n",
+	)
+
+	resolved, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	gen, vote := resolved.Profile.Sampling[types.PhaseGenerate], resolved.Profile.Sampling[types.PhaseVote]
+	if *gen.Temperature != 0.7 || gen.MaxTokens != 8192 || *vote.Temperature != 0.2 {
+		t.Errorf("sampling = %+v, want generate 0.7 with 8192 tokens and vote 0.2", resolved.Profile.Sampling)
+	}
+}
+
+func TestMergeSampling(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name     string
+		base     map[types.Phase]types.SamplingParams
+		override map[types.Phase]types.SamplingParams
+		want     map[types.Phase]types.SamplingParams
+	}{
+		{name: "both empty"},
+		{
+			name: "override only",
+			override: map[types.Phase]types.SamplingParams{
+				types.PhaseVote: {Temperature: f(0.2)},
+			},
+			want: map[types.Phase]types.SamplingParams{
+				types.PhaseVote: {Temperature: f(0.2)},
+			},
+		},
+		{
+			name: "fields overlaid within a phase",
+			base: map[types.Phase]types.SamplingParams{
+				types.PhaseGenerate: {Temperature: f(1), TopP: f(0.9), MaxTokens: 8192, StopSequences: []string{"###"}},
+			},
+			override: map[types.Phase]types.SamplingParams{
+				types.PhaseGenerate: {Temperature: f(0.5), ThinkingBudget: 2048},
+			},
+			want: map[types.Phase]types.SamplingParams{
+				types.PhaseGenerate: {Temperature: f(0.5), TopP: f(0.9), MaxTokens: 8192, StopSequences: []string{"###"}, ThinkingBudget: 2048},
+			},
+		},
+		{
+			name: "other phases kept",
+			base: map[types.Phase]types.SamplingParams{
+				types.PhaseGenerate: {MaxTokens: 8192},
+			},
+			override: map[types.Phase]types.SamplingParams{
+				types.PhaseVote: {MaxTokens: 1024},
+			},
+			want: map[types.Phase]types.SamplingParams{
+				types.PhaseGenerate: {MaxTokens: 8192},
+				types.PhaseVote:     {MaxTokens: 1024},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeSampling(tt.base, tt.override); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeSampling = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package council
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+// shingleSize is the number of words in each shingle compared between solutions
+const shingleSize = 3
+
+var nonWordPattern = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// ClusterSolutions groups solutions whose text similarity reaches the
+// threshold. Grouping is single-linkage: a solution joins a cluster when it is
+// similar enough to any one member. Every solution ends up in a cluster.
+func (c *Council) ClusterSolutions() {
+	solutions := c.session.Solutions
+	n := len(solutions)
+
+	sets := make([]map[string]bool, n)
+	for i, sol := range solutions {
+		sets[i] = shingles(sol.Content)
+	}
+
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	var root func(i int) int
+	root = func(i int) int {
+		if parent[i] != i {
+			parent[i] = root(parent[i])
+		}
+		return parent[i]
+	}
+
+	sim := make([][]float64, n)
+	for i := range sim {
+		sim[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			sim[i][j] = similarity(sets[i], sets[j])
+			sim[j][i] = sim[i][j]
+			if sim[i][j] >= c.config.Cluster.Threshold {
+				parent[root(j)] = root(i)
+			}
+		}
+	}
+
+	members := make(map[int][]int) // Root index -> solution indexes
+	for i := 0; i < n; i++ {
+		members[root(i)] = append(members[root(i)], i)
+	}
+
+	var clusters []types.Cluster
+	for _, indexes := range members {
+		cluster := types.Cluster{}
+		total, pairs := 0.0, 0
+		for a, i := range indexes {
+			cluster.AgentIDs = append(cluster.AgentIDs, solutions[i].AgentID)
+			for _, j := range indexes[a+1:] {
+				total += sim[i][j]
+				pairs++
+			}
+		}
+		if pairs > 0 {
+			cluster.Similarity = total / float64(pairs)
+		}
+		sort.Ints(cluster.AgentIDs)
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].AgentIDs[0] < clusters[j].AgentIDs[0]
+	})
+
+	c.session.Clusters = clusters
+}
+
+// shingles returns the set of word n-grams in text, ignoring case and punctuation
+func shingles(text string) map[string]bool {
+	words := strings.Fields(nonWordPattern.ReplaceAllString(strings.ToLower(text), " "))
+	set := make(map[string]bool)
+	if len(words) < shingleSize {
+		if len(words) > 0 {
+			set[strings.Join(words, " ")] = true
+		}
+		return set
+	}
+	for i := 0; i+shingleSize <= len(words); i++ {
+		set[strings.Join(words[i:i+shingleSize], " ")] = true
+	}
+	return set
+}
+
+// similarity returns the Jaccard similarity of two shingle sets, from 0 to 1
+func similarity(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	shared := 0
+	for s := range a {
+		if b[s] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// clusterSummary describes the clusters for the phase status line
+func (c *Council) clusterSummary() string {
+	var groups []string
+	for _, cluster := range c.session.Clusters {
+		if len(cluster.AgentIDs) > 1 {
+			groups = append(groups, fmt.Sprintf("%s (%.2f)", c.agentLabels(cluster.AgentIDs), cluster.Similarity))
+		}
+	}
+	if len(groups) == 0 {
+		return fmt.Sprintf("no near-duplicates among %d solutions", len(c.session.Solutions))
+	}
+	return fmt.Sprintf("%d clusters from %d solutions: %s", len(c.session.Clusters), len(c.session.Solutions), strings.Join(groups, "; "))
+}
+
+// tallyClusters decides the vote over clusters instead of single solutions.
+// Each vote's ranking is collapsed to clusters, in order of each cluster's
+// best-ranked member, and scored with Borda count over the clusters. A voter
+// still never ranks its own solution, but can rank its cluster through the
+// other members. The winning cluster's member with the most points of its own
+// (the lowest ID on a tie) is the winner.
+func (c *Council) tallyClusters() {
+	clusterOf := make(map[int]int) // AgentID -> index into the clusters
+	for i, cluster := range c.session.Clusters {
+		for _, id := range cluster.AgentIDs {
+			clusterOf[id] = i
+		}
+	}
+
+	n := len(c.session.Clusters)
+	points := make(map[int]float64)
+	for i := range c.session.Clusters {
+		points[i] = 0
+	}
+	for _, vote := range c.session.Votes {
+		seen := make(map[int]bool)
+		place := 0
+		for _, id := range vote.Rankings {
+			k := clusterOf[id]
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			if p := n - 1 - place; p > 0 {
+				points[k] += float64(p) * c.effectiveWeight(vote)
+			}
+			place++
+		}
+	}
+
+	for i := range c.session.Clusters {
+		c.session.Clusters[i].Points = points[i]
+	}
+
+	var winners []int
+	for _, k := range leaders(points) {
+		winners = append(winners, c.bestMember(c.session.Clusters[k]))
+	}
+	sort.Ints(winners)
+	c.setWinners(winners)
+}
+
+// bestMember returns the member of a cluster with the most points of its own,
+// weighted if the vote was, or the lowest ID on a tie
+func (c *Council) bestMember(cluster types.Cluster) int {
+	best := cluster.AgentIDs[0]
+	for _, id := range cluster.AgentIDs[1:] {
+		if c.ownPoints(id) > c.ownPoints(best) {
+			best = id
+		}
+	}
+	return best
+}
+
+// ownPoints returns an agent's points from the tally, weighted if the vote was
+func (c *Council) ownPoints(id int) float64 {
+	if weighted, ok := c.session.WeightedScores[id]; ok {
+		return weighted
+	}
+	return float64(c.session.Scores[id])
+}
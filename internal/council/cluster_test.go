@@ -0,0 +1,142 @@
+package council
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+func TestShingles(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"", nil},
+		{"Hello, world!", []string{"hello world"}},
+		{"The cache; the CACHE evicts", []string{"cache the cache", "the cache evicts", "the cache the"}},
+		{"a b c d", []string{"a b c", "b c d"}},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for s := range shingles(tt.text) {
+			got = append(got, s)
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("shingles(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"", "", 1},
+		{"a b c", "", 0},
+		{"a b c d", "a b c d", 1},
+		{"A, b. C d!", "a b c d", 1},
+		{"a b c d", "b c d e", 1.0 / 3},
+		{"a b c", "x y z", 0},
+	}
+
+	for _, tt := range tests {
+		if got := similarity(shingles(tt.a), shingles(tt.b)); !near(got, tt.want) {
+			t.Errorf("similarity(%q, %q) = %g, want %g", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestClusterSolutions(t *testing.T) {
+	const (
+		a = "one two three four five six seven eight nine ten"
+		b = "four five six seven eight nine ten eleven twelve thirteen"
+		c = "seven eight nine ten eleven twelve thirteen fourteen fifteen sixteen"
+		d = "an unrelated answer about something else entirely"
+	)
+
+	tests := []struct {
+		name      string
+		contents  []string
+		threshold float64
+		want      [][]int
+	}{
+		{
+			name:      "chain merges transitively",
+			contents:  []string{a, b, c, d},
+			threshold: 0.4,
+			want:      [][]int{{1, 2, 3}, {4}},
+		},
+		{
+			name:      "order doesn't matter",
+			contents:  []string{c, d, a, b},
+			threshold: 0.4,
+			want:      [][]int{{1, 3, 4}, {2}},
+		},
+		{
+			name:      "threshold above every pair",
+			contents:  []string{a, b, c},
+			threshold: 0.9,
+			want:      [][]int{{1}, {2}, {3}},
+		},
+		{
+			name:      "exact copies",
+			contents:  []string{d, a, d},
+			threshold: 1,
+			want:      [][]int{{1, 3}, {2}},
+		},
+	}
+
+	// The chain only tests transitivity if its ends are dissimilar
+	if similarity(shingles(a), shingles(c)) >= 0.4 || similarity(shingles(a), shingles(b)) < 0.4 || similarity(shingles(b), shingles(c)) < 0.4 {
+		t.Fatal("test solutions don't form a chain at threshold 0.4")
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			council := testCouncil(len(tt.contents), &types.Config{Cluster: &types.ClusterConfig{Threshold: tt.threshold}})
+			for i, content := range tt.contents {
+				council.session.Solutions = append(council.session.Solutions, types.Solution{AgentID: i + 1, Content: content})
+			}
+
+			council.ClusterSolutions()
+
+			var got [][]int
+			for _, cluster := range council.session.Clusters {
+				got = append(got, cluster.AgentIDs)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("clusters = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTallyClusters(t *testing.T) {
+	c := testCouncil(4, &types.Config{Cluster: &types.ClusterConfig{Threshold: 0.8, Tally: true}})
+	c.session.Clusters = []types.Cluster{{AgentIDs: []int{1, 2}}, {AgentIDs: []int{3}}, {AgentIDs: []int{4}}}
+	// Copies 1 and 2 split their support, so by solution 2 would only tie with 3
+	c.session.Votes = []types.Vote{
+		{VoterID: 1, Rankings: []int{3, 2, 4}},
+		{VoterID: 2, Rankings: []int{3, 1, 4}},
+		{VoterID: 3, Rankings: []int{2, 4, 1}},
+		{VoterID: 4, Rankings: []int{2, 3, 1}},
+	}
+
+	c.Tally()
+
+	// By cluster, with 3 clusters: {1,2} gets 1+1+2+2, {3} gets 2+2+1, {4} gets 0+0+1
+	want := []float64{6, 5, 1}
+	for i, cluster := range c.session.Clusters {
+		if !near(cluster.Points, want[i]) {
+			t.Errorf("cluster %v points = %g, want %g", cluster.AgentIDs, cluster.Points, want[i])
+		}
+	}
+	if c.session.WinnerID == nil || *c.session.WinnerID != 2 {
+		t.Errorf("winner = %v, want 2, the member with more points of its own", c.session.WinnerID)
+	}
+}
@@ -0,0 +1,79 @@
+package council
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+// StrawPoll takes an interim vote after a discussion round and measures how
+// far the council agrees on the leader. The votes, including the human's, are
+// weighted as in Tally, so a consensus is one the final tally would see, but
+// they don't count towards the result.
+func (c *Council) StrawPoll(ctx context.Context, round int) *types.StrawPoll {
+	votes := c.collectVotes(ctx, c.voters(), c.agentSolutions(), c.session.Critiques, c.moderation(round))
+	if c.humanVote != nil {
+		votes = append(votes, *c.humanVote)
+	}
+	c.weigh(votes)
+
+	poll := types.StrawPoll{
+		Round:  round,
+		Votes:  votes,
+		Scores: borda(votes, c.candidates()),
+	}
+
+	ids := leaders(poll.Scores)
+	if c.weighted(votes) {
+		poll.WeightedScores = c.weightedBorda(votes, c.candidates())
+		ids = leaders(poll.WeightedScores)
+	}
+	if len(ids) == 1 {
+		poll.LeaderID = &ids[0]
+		poll.Agreement = c.agreement(votes, ids[0])
+		poll.Consensus = poll.Agreement >= c.config.Consensus.Threshold
+	}
+
+	c.session.StrawPolls = append(c.session.StrawPolls, poll)
+	c.PrintVerboseStrawPoll(&poll)
+
+	return &poll
+}
+
+// agreement returns the share of the voters' weight, other than the leader's
+// own, behind votes ranking the leader first. Voters who abstained are left
+// out; voters whose vote failed are counted as disagreeing.
+func (c *Council) agreement(votes []types.Vote, leader int) float64 {
+	total, agree := 0.0, 0.0
+	for _, vote := range votes {
+		if vote.VoterID == leader || vote.Status == types.VoteAbstained {
+			continue
+		}
+		weight := c.effectiveWeight(vote)
+		total += weight
+		if len(vote.Rankings) > 0 && vote.Rankings[0] == leader {
+			agree += weight
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return agree / total
+}
+
+// pollSummary describes a straw poll's outcome in one line
+func (c *Council) pollSummary(poll *types.StrawPoll) string {
+	if poll.LeaderID == nil {
+		return "no clear leader"
+	}
+	return fmt.Sprintf("%s leads, ranked first by %.0f%% of the other agents%s", c.session.AgentLabel(*poll.LeaderID), poll.Agreement*100, byWeight(poll))
+}
+
+// byWeight qualifies a poll's agreement when it was measured by vote weight
+func byWeight(poll *types.StrawPoll) string {
+	if poll.WeightedScores != nil {
+		return " by vote weight"
+	}
+	return ""
+}
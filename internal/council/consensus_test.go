@@ -0,0 +1,77 @@
+package council
+
+import (
+	"testing"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+func TestAgreement(t *testing.T) {
+	tests := []struct {
+		name    string
+		weigh   bool
+		weights map[int]float64
+		votes   []types.Vote
+		leader  int
+		want    float64
+	}{
+		{
+			name: "leader's own vote left out",
+			votes: []types.Vote{
+				{VoterID: 1, Rankings: []int{2, 3}},
+				{VoterID: 2, Rankings: []int{3, 1}},
+				{VoterID: 3, Rankings: []int{1, 2}},
+			},
+			leader: 1,
+			want:   0.5,
+		},
+		{
+			name: "abstention left out, failure disagrees",
+			votes: []types.Vote{
+				{VoterID: 2, Rankings: []int{1, 3}},
+				{VoterID: 3, Status: types.VoteAbstained},
+				{VoterID: 4, Status: types.VoteFailed},
+			},
+			leader: 1,
+			want:   0.5,
+		},
+		{
+			name:    "heavy dissenter outweighs",
+			weights: map[int]float64{4: 3},
+			votes: []types.Vote{
+				{VoterID: 2, Rankings: []int{1, 3}},
+				{VoterID: 3, Rankings: []int{1, 2}},
+				{VoterID: 4, Rankings: []int{2, 1}},
+			},
+			leader: 1,
+			want:   0.4,
+		},
+		{
+			name:  "confidence weighs agreement",
+			weigh: true,
+			votes: []types.Vote{
+				{VoterID: 2, Rankings: []int{1, 3}, Confidence: 0.2},
+				{VoterID: 3, Rankings: []int{2, 1}, Confidence: 0.8},
+			},
+			leader: 1,
+			want:   0.2,
+		},
+		{
+			name:   "nobody left to agree",
+			votes:  []types.Vote{{VoterID: 2, Status: types.VoteAbstained}},
+			leader: 1,
+			want:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testCouncil(4, &types.Config{WeighConfidence: tt.weigh})
+			c.session.Weights = tt.weights
+			c.weigh(tt.votes)
+			if got := c.agreement(tt.votes, tt.leader); !near(got, tt.want) {
+				t.Errorf("agreement = %g, want %g", got, tt.want)
+			}
+		})
+	}
+}
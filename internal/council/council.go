@@ -2,24 +2,37 @@ package council
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/humzahkiani/council/internal/agent"
+	"github.com/humzahkiani/council/internal/attach"
+	"github.com/humzahkiani/council/internal/prompt"
 	"github.com/humzahkiani/council/internal/storage"
 	"github.com/humzahkiani/council/internal/types"
 )
 
 // Council orchestrates the multi-agent deliberation process
 type Council struct {
-	config  *types.Config
-	client  *agent.Client
-	storage *storage.Storage
-	agents  []*agent.Agent
-	session *types.Session
+	config      *types.Config
+	client      *agent.Client
+	judgeClient *agent.Client // Separate client when the judges use another model; nil shares client
+	storage     *storage.Storage
+	agents      []*agent.Agent
+	judges      []*agent.Agent // Separate judges that critique and vote instead of the agents; empty if none
+	moderator   *agent.Agent   // nil unless the run is moderated
+	finalists   []int          // Agents still in contention after a tournament's group stage; nil means all
+	reviewer    Reviewer       // Asks the human participant for their review; required with Config.Human
+	humanVote   *types.Vote    // The human's vote, added to the agents' votes; nil if there is none
+	session     *types.Session
+	progress    io.Writer // Header, phase status and verbose output
+	savedPath   string
 }
 
 // New creates a new Council instance
@@ -31,8 +44,30 @@ func New(config *types.Config) (*Council, error) {
 
 	client := agent.NewClient(apiKey, config.Model)
 
+	prompts, err := prompt.Load(config.PromptsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load prompt templates: %w", err)
+	}
+
+	contextFiles, err := attach.LoadFiles(config.ContextPaths, config.ContextMaxFileBytes, config.ContextMaxTotalBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load context files: %w", err)
+	}
+	packedContext := attach.Pack(contextFiles)
+
+	images, err := attach.LoadImages(config.ImagePaths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load images: %w", err)
+	}
+	var imageBlocks []agent.ContentBlock
+	for _, img := range images {
+		imageBlocks = append(imageBlocks,
+			agent.TextBlock(fmt.Sprintf("Attached image: %s", img.Path)),
+			agent.ImageBlock(img.MediaType, img.Data),
+		)
+	}
+
 	var store *storage.Storage
-	var err error
 	if config.Save || config.OutputPath != "" {
 		store, err = storage.New()
 		if err != nil {
@@ -40,35 +75,115 @@ func New(config *types.Config) (*Council, error) {
 		}
 	}
 
-	// Create agents
+	// Create agents, assigning personas round-robin if any were configured
 	agents := make([]*agent.Agent, config.AgentCount)
+	personas := make(map[int]string)
 	for i := 0; i < config.AgentCount; i++ {
 		agents[i] = agent.New(i+1, config.AgentCount, client)
+		agents[i].Prompts = prompts
+		agents[i].Sampling = config.Sampling
+		agents[i].Context = packedContext
+		agents[i].Images = imageBlocks
+		agents[i].Rubric = config.Rubric
+		if len(config.Personas) > 0 {
+			persona := config.Personas[i%len(config.Personas)]
+			agents[i].Persona = &persona
+			personas[i+1] = persona.Name
+		}
+	}
+
+	// Judges are numbered after the agents and, with --judge-model, get their own client
+	var judgeClient *agent.Client
+	judgesClient := client
+	if config.JudgeModel != "" && config.JudgeModel != config.Model {
+		judgeClient = agent.NewClient(apiKey, config.JudgeModel)
+		judgesClient = judgeClient
+	}
+	judges := make([]*agent.Agent, config.Judges)
+	for i := range judges {
+		judges[i] = agent.NewJudge(i+1, config.AgentCount, judgesClient)
+		judges[i].Prompts = prompts
+		judges[i].Sampling = config.Sampling
+		judges[i].Context = packedContext
+		judges[i].Images = imageBlocks
+		judges[i].Rubric = config.Rubric
+	}
+
+	var moderator *agent.Agent
+	if config.Moderated() {
+		moderator = agent.NewModerator(config.AgentCount, client)
+		moderator.Prompts = prompts
+		moderator.Sampling = config.Sampling
+		moderator.Context = packedContext
+		moderator.Images = imageBlocks
 	}
 
 	// Initialize session
 	session := &types.Session{
-		ID:         uuid.New().String(),
-		Task:       config.Task,
-		AgentCount: config.AgentCount,
-		Rounds:     config.Rounds,
-		Model:      config.Model,
-		Solutions:  []types.Solution{},
-		Critiques:  []types.Critique{},
-		Votes:      []types.Vote{},
-		Scores:     make(map[int]int),
-		CreatedAt:  time.Now(),
-	}
-
-	return &Council{
-		config:  config,
-		client:  client,
-		storage: store,
-		agents:  agents,
-		session: session,
-	}, nil
+		ID:           uuid.New().String(),
+		Task:         config.Task,
+		AgentCount:   config.AgentCount,
+		Judges:       config.Judges,
+		Rounds:       config.Rounds,
+		Model:        config.Model,
+		Voting:       config.Voting,
+		Mode:         config.Mode,
+		Profile:      config.Profile,
+		Config:       config,
+		Personas:     personas,
+		PromptHash:   prompts.Hash(),
+		Sampling:     config.Sampling,
+		ContextFiles: attach.Records(contextFiles),
+		Images:       attach.ImageRecords(images),
+		Solutions:    []types.Solution{},
+		Critiques:    []types.Critique{},
+		Votes:        []types.Vote{},
+		Scores:       make(map[int]int),
+		CreatedAt:    time.Now(),
+	}
+
+	// Self-consistency mode skips the discussion
+	if config.Mode == types.ModeSelfConsistency {
+		session.Rounds = 0
+	}
+
+	c := &Council{
+		config:      config,
+		client:      client,
+		judgeClient: judgeClient,
+		storage:     store,
+		agents:      agents,
+		judges:      judges,
+		moderator:   moderator,
+		session:     session,
+		progress:    os.Stdout,
+	}
+	if err := c.resolveWeights(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// SetProgress redirects progress output, e.g. to stderr when stdout carries machine-readable results
+func (c *Council) SetProgress(w io.Writer) {
+	c.progress = w
 }
 
+// Session returns the council's session
+func (c *Council) Session() *types.Session {
+	return c.session
+}
+
+// SavedPath returns where the session was saved, or "" if it wasn't
+func (c *Council) SavedPath() string {
+	return c.savedPath
+}
+
+// ErrBudgetExceeded is returned by Run when the token budget ran out before the
+// last phase. The partial session is still saved and available via Session.
+var ErrBudgetExceeded = errors.New("token budget exceeded")
+
 // Run executes the full council process: generate -> discuss -> vote -> tally
 func (c *Council) Run(ctx context.Context) error {
 	c.printHeader()
@@ -79,18 +194,94 @@ func (c *Council) Run(ctx context.Context) error {
 		return fmt.Errorf("generation phase failed: %w", err)
 	}
 	c.printPhaseDone()
+	if err := c.checkBudget(types.PhaseGenerate, 0); err != nil {
+		return err
+	}
 
-	// Phase 2: Discussion rounds
+	// Optional: run each solution's code against the test command
+	if c.config.Verify != nil {
+		c.printPhase("Verifying solutions")
+		c.Verify(ctx)
+		fmt.Fprintln(c.progress, c.verificationSummary())
+	}
+
+	// Optional: group near-duplicate solutions
+	if c.config.Cluster != nil {
+		c.printPhase("Clustering solutions")
+		c.ClusterSolutions()
+		fmt.Fprintln(c.progress, c.clusterSummary())
+	}
+
+	// Self-consistency mode: the most common final answer wins, without discussion or voting
+	if c.config.Mode == types.ModeSelfConsistency {
+		c.printPhase("Counting answers")
+		c.SelfConsistency()
+		fmt.Fprintln(c.progress, c.answerSummary())
+		c.finish()
+		return nil
+	}
+
+	// Optional: a human critiques and ranks the anonymised solutions
+	if c.config.Human != nil {
+		if c.reviewer == nil {
+			return fmt.Errorf("a human participant requires a reviewer")
+		}
+		fmt.Fprintln(c.progress, "Waiting for your review...")
+		if err := c.Review(); err != nil {
+			return err
+		}
+	}
+
+	// Tournament mode: groups narrow the field before the discussion and vote
+	discussLabel, voteLabel := "Discussion round %d", "Voting"
+	if c.config.Mode == types.ModeTournament {
+		if err := c.GroupStage(ctx); err != nil {
+			return err
+		}
+		discussLabel, voteLabel = "Final discussion round %d", "Final voting"
+	}
+
+	// Phase 2: Discussion rounds (the final, in tournament mode)
 	for round := 1; round <= c.config.Rounds; round++ {
-		c.printPhase(fmt.Sprintf("Discussion round %d", round))
+		c.printPhase(fmt.Sprintf(discussLabel, round))
 		if err := c.Discuss(ctx, round); err != nil {
 			return fmt.Errorf("discussion phase failed: %w", err)
 		}
 		c.printPhaseDone()
+		if err := c.checkBudget(types.PhaseDiscuss, round); err != nil {
+			return err
+		}
+
+		if c.moderator != nil {
+			c.printPhase(fmt.Sprintf("Moderating round %d", round))
+			note := c.Moderate(ctx, round)
+			c.printPhaseDone()
+			if err := c.checkBudget(types.PhaseModerate, round); err != nil {
+				return err
+			}
+			if note != nil && !note.Continue && round < c.config.Rounds {
+				c.endDiscussion(types.StopModerator, round, note.Reason)
+				break
+			}
+		}
+
+		// Optional: skip the remaining rounds once a straw poll shows consensus
+		if c.config.Consensus != nil && round < c.config.Rounds {
+			c.printPhase(fmt.Sprintf("Straw poll after round %d", round))
+			poll := c.StrawPoll(ctx, round)
+			fmt.Fprintln(c.progress, c.pollSummary(poll))
+			if err := c.checkBudget(types.PhaseDiscuss, round); err != nil {
+				return err
+			}
+			if poll.Consensus {
+				c.endDiscussion(types.StopConsensus, round, fmt.Sprintf("%s (threshold %.0f%%)", c.pollSummary(poll), c.config.Consensus.Threshold*100))
+				break
+			}
+		}
 	}
 
 	// Phase 3: Voting
-	c.printPhase("Voting")
+	c.printPhase(voteLabel)
 	if err := c.Vote(ctx); err != nil {
 		return fmt.Errorf("voting phase failed: %w", err)
 	}
@@ -98,52 +289,148 @@ func (c *Council) Run(ctx context.Context) error {
 
 	// Phase 4: Tally
 	c.Tally()
+
+	// Optional: merge the top solutions into a final answer
+	if c.config.Synthesis != nil {
+		if err := c.checkBudget(types.PhaseVote, 0); err != nil {
+			return err
+		}
+
+		c.printPhase("Synthesizing")
+		if err := c.Synthesize(ctx); err != nil {
+			return fmt.Errorf("synthesis phase failed: %w", err)
+		}
+		c.printPhaseDone()
+	}
+
+	c.finish()
+
+	return nil
+}
+
+// endDiscussion records skipping the discussion rounds after round
+func (c *Council) endDiscussion(reason types.StopReason, round int, detail string) {
+	c.session.DiscussionEnd = &types.Stop{
+		Reason: reason,
+		Phase:  types.PhaseDiscuss,
+		Round:  round,
+		Detail: detail,
+		At:     time.Now(),
+	}
+	fmt.Fprintf(c.progress, "Ending discussion after round %d of %d: %s (%s)\n", round, c.config.Rounds, reason, detail)
+}
+
+// checkBudget stops the run once the token budget is used up. The budget is
+// checked between phases, so a run can overshoot it by up to one phase.
+func (c *Council) checkBudget(phase types.Phase, round int) error {
+	usage := c.usage()
+	if c.config.TokenBudget <= 0 || usage.Total() < c.config.TokenBudget {
+		return nil
+	}
+
+	c.session.Stop = &types.Stop{
+		Reason: types.StopBudget,
+		Phase:  phase,
+		Round:  round,
+		Detail: fmt.Sprintf("used %d of %d tokens", usage.Total(), c.config.TokenBudget),
+		At:     time.Now(),
+	}
+	fmt.Fprintf(c.progress, "\nStopping: %s (%s)\n", ErrBudgetExceeded, c.session.Stop.Detail)
+	c.finish()
+
+	return ErrBudgetExceeded
+}
+
+// usage returns the tokens used so far, across the judges' client if they have their own
+func (c *Council) usage() types.Usage {
+	usage := c.client.Usage()
+	if c.judgeClient != nil {
+		judge := c.judgeClient.Usage()
+		usage.InputTokens += judge.InputTokens
+		usage.OutputTokens += judge.OutputTokens
+	}
+	return usage
+}
+
+// finish records usage and completion time, then saves the session if requested
+func (c *Council) finish() {
+	c.session.Usage = c.usage()
 	c.session.CompletedAt = time.Now()
 
-	// Save session if requested
 	if err := c.saveSession(); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to save session: %v\n", err)
 	}
-
-	return nil
 }
 
 // Output prints the final results
 func (c *Council) Output() {
 	fmt.Println()
+
+	if stop := c.session.Stop; stop != nil {
+		fmt.Printf("Stopped early after %s: %s (%s)\n", c.stopPoint(stop), stop.Reason, stop.Detail)
+		if len(c.session.Votes) == 0 && len(c.session.Judgments) == 0 {
+			fmt.Printf("%d solution(s) and %d critique(s) were collected; no votes were tallied.\n", len(c.session.Solutions), len(c.session.Critiques))
+			return
+		}
+		fmt.Println()
+	}
+
+	c.outputBracket()
+
+	if end := c.session.DiscussionEnd; end != nil {
+		fmt.Printf("Discussion ended after round %d of %d: %s (%s)\n\n", end.Round, c.config.Rounds, end.Reason, end.Detail)
+	}
+
 	fmt.Println("Results")
 	fmt.Println("-------")
 
-	// Sort agent IDs for consistent output
+	// Agent IDs in order, for the rubric breakdown
 	var agentIDs []int
 	for id := range c.session.Scores {
 		agentIDs = append(agentIDs, id)
 	}
 	sort.Ints(agentIDs)
 
-	for _, id := range agentIDs {
+	// Best first, by the score that decided the result
+	for _, st := range c.session.Standings() {
+		id := st.AgentID
 		score := c.session.Scores[id]
 		marker := ""
+		if c.session.TalliedByCluster() {
+			marker = fmt.Sprintf(", cluster %.1f points", st.Score)
+		}
 		if c.session.WinnerID != nil && *c.session.WinnerID == id {
-			marker = " * WINNER"
+			marker += " * WINNER"
+		}
+		if answer, ok := c.session.Answers[id]; ok {
+			fmt.Printf("%s: %s (%d of %d solutions)%s\n", c.session.AgentLabel(id), answerText(answer), score, len(c.session.Solutions), marker)
+		} else if strength, ok := c.session.Strengths[id]; ok {
+			fmt.Printf("%s: %d pairwise wins, strength %.3f%s\n", c.session.AgentLabel(id), score, strength, marker)
+		} else if weighted, ok := c.session.WeightedScores[id]; ok {
+			fmt.Printf("%s: %.1f weighted points (%d unweighted)%s\n", c.session.AgentLabel(id), weighted, score, marker)
+		} else {
+			fmt.Printf("%s: %d points%s\n", c.session.AgentLabel(id), score, marker)
 		}
-		fmt.Printf("Agent %d: %d points%s\n", id, score, marker)
 	}
 
 	fmt.Println()
 
+	c.outputTurnout()
+	c.outputClusters()
+	c.outputRubric(agentIDs)
+
 	if c.session.IsTie {
 		fmt.Printf("TIE between Agents %v\n\n", c.session.TiedAgents)
 		fmt.Println("All tied solutions are shown below for your review:")
 		for _, sol := range c.session.Solutions {
 			for _, id := range c.session.TiedAgents {
 				if sol.AgentID == id {
-					fmt.Printf("\n--- Solution (Agent %d) ---\n%s\n", sol.AgentID, sol.Content)
+					fmt.Printf("\n--- Solution (%s) ---\n%s\n", c.session.AgentLabel(sol.AgentID), sol.Content)
 				}
 			}
 		}
 	} else if c.session.WinnerID != nil {
-		fmt.Printf("Winning Solution (Agent %d)\n", *c.session.WinnerID)
+		fmt.Printf("Winning Solution (%s)\n", c.session.AgentLabel(*c.session.WinnerID))
 		fmt.Println("--------------------------")
 		for _, sol := range c.session.Solutions {
 			if sol.AgentID == *c.session.WinnerID {
@@ -152,24 +439,243 @@ func (c *Council) Output() {
 			}
 		}
 	}
+
+	c.outputSynthesis()
+}
+
+// outputTurnout prints how many votes were cast, if any voter abstained or failed to vote
+func (c *Council) outputTurnout() {
+	if len(c.session.Abstentions) == 0 && len(c.session.FailedVotes) == 0 {
+		return
+	}
+
+	cast := 0
+	for _, vote := range c.session.Votes {
+		if vote.Cast() {
+			cast++
+		}
+	}
+	fmt.Printf("Votes: %d cast", cast)
+	if len(c.session.Abstentions) > 0 {
+		fmt.Printf(", %d abstained (%s)", len(c.session.Abstentions), c.agentLabels(c.session.Abstentions))
+	}
+	if len(c.session.FailedVotes) > 0 {
+		fmt.Printf(", %d failed (%s)", len(c.session.FailedVotes), c.agentLabels(c.session.FailedVotes))
+	}
+	fmt.Println()
+	if cast == 0 {
+		fmt.Println("No votes were cast, so every solution is tied.")
+	}
+	fmt.Println()
+}
+
+// outputClusters prints the clusters of near-duplicate solutions, or every
+// cluster's points when the vote was tallied by cluster
+func (c *Council) outputClusters() {
+	tallied := c.config.Cluster != nil && c.config.Cluster.Tally
+	var lines []string
+	for _, cluster := range c.session.Clusters {
+		line := c.agentLabels(cluster.AgentIDs)
+		if len(cluster.AgentIDs) > 1 {
+			line += fmt.Sprintf(" (similarity %.2f)", cluster.Similarity)
+		} else if !tallied {
+			continue
+		}
+		if tallied {
+			line += fmt.Sprintf(": %.1f points", cluster.Points)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	if tallied {
+		fmt.Println("Clusters (decide the vote)")
+		fmt.Println("--------------------------")
+	} else {
+		fmt.Println("Clusters")
+		fmt.Println("--------")
+	}
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	fmt.Println()
+}
+
+// agentLabels joins the display labels of the given agents, judges or human
+func (c *Council) agentLabels(ids []int) string {
+	labels := make([]string, len(ids))
+	for i, id := range ids {
+		labels[i] = c.session.AgentLabel(id)
+	}
+	return strings.Join(labels, ", ")
+}
+
+// outputRubric prints each solution's mean score per rubric criterion, if voters scored any
+func (c *Council) outputRubric(agentIDs []int) {
+	if len(c.session.RubricTotals) == 0 {
+		return
+	}
+
+	fmt.Println("Rubric")
+	fmt.Println("------")
+	for _, id := range agentIDs {
+		scores, ok := c.session.RubricScores[id]
+		if !ok {
+			continue
+		}
+		var parts []string
+		for _, criterion := range c.config.Rubric {
+			if score, ok := scores[criterion.Name]; ok {
+				parts = append(parts, fmt.Sprintf("%s %.1f", criterion.Name, score))
+			}
+		}
+		fmt.Printf("%s: %.2f (%s)\n", c.session.AgentLabel(id), c.session.RubricTotals[id], strings.Join(parts, ", "))
+	}
+	fmt.Println()
+}
+
+// weightSummary describes the vote weights, e.g. "Agent 1 1.20, Agent 2 0.80"
+func (c *Council) weightSummary() string {
+	if len(c.session.Weights) == 0 {
+		return "none derived, no past votes match these voters"
+	}
+	ids := make([]int, 0, len(c.session.Weights))
+	for id := range c.session.Weights {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	var parts []string
+	for _, id := range ids {
+		parts = append(parts, fmt.Sprintf("%s %.2f", c.session.AgentLabel(id), c.session.Weights[id]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// stopPoint describes the last phase completed before a stop
+func (c *Council) stopPoint(stop *types.Stop) string {
+	if stop.Round > 0 {
+		return fmt.Sprintf("%s round %d", stop.Phase, stop.Round)
+	}
+	return string(stop.Phase)
+}
+
+// outputBracket prints a tournament's group results, if there are any
+func (c *Council) outputBracket() {
+	bracket := c.session.Bracket
+	if bracket == nil {
+		return
+	}
+
+	fmt.Println("Group Stage")
+	fmt.Println("-----------")
+	for _, g := range bracket.Groups {
+		fmt.Printf("Group %d (Agents %s): %s\n", g.ID, joinIDs(g.AgentIDs), c.advanced(g))
+	}
+	fmt.Println()
+}
+
+// outputSynthesis prints the synthesized answer, if there is one
+func (c *Council) outputSynthesis() {
+	syn := c.session.Synthesis
+	if syn == nil {
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("Synthesis by %s (from Agents %v)\n", c.session.AgentLabel(syn.AgentID), syn.SourceIDs)
+	fmt.Println("--------------------------")
+	fmt.Println(syn.Content)
 }
 
 // printHeader prints the initial header
 func (c *Council) printHeader() {
-	fmt.Println("Council of Elders")
-	fmt.Println("====================")
-	fmt.Printf("Task: %s\n", c.session.Task)
-	fmt.Printf("Agents: %d | Rounds: %d | Model: %s\n\n", c.config.AgentCount, c.config.Rounds, c.config.Model)
+	fmt.Fprintln(c.progress, "Council of Elders")
+	fmt.Fprintln(c.progress, "====================")
+	fmt.Fprintf(c.progress, "Task: %s\n", summarizeTask(c.session.Task))
+	fmt.Fprintf(c.progress, "Agents: %d | Rounds: %d | Model: %s\n", c.config.AgentCount, c.session.Rounds, c.config.Model)
+	switch c.config.Mode {
+	case types.ModeTournament:
+		fmt.Fprintf(c.progress, "Mode: tournament (groups of about %d)\n", c.config.GroupSize)
+	case types.ModeSelfConsistency:
+		fmt.Fprintln(c.progress, "Mode: self-consistency (majority final answer, no discussion or vote)")
+	}
+	if len(c.judges) > 0 {
+		judgeModel := c.config.Model
+		if c.config.JudgeModel != "" {
+			judgeModel = c.config.JudgeModel
+		}
+		fmt.Fprintf(c.progress, "Judges: %d | Model: %s\n", len(c.judges), judgeModel)
+	}
+	if c.config.AccuracyWeights || len(c.session.Weights) > 0 {
+		fmt.Fprintf(c.progress, "Vote weights: %s\n", c.weightSummary())
+	}
+	if c.config.WeighConfidence {
+		fmt.Fprintln(c.progress, "Vote confidence: multiplies each vote's weight")
+	}
+	if c.config.Human != nil {
+		fmt.Fprintf(c.progress, "Human: critiques and votes with weight %g\n", c.config.Human.Weight)
+	}
+	if c.moderator != nil {
+		fmt.Fprintln(c.progress, "Moderator: on")
+	}
+	if c.config.Consensus != nil {
+		fmt.Fprintf(c.progress, "Early stop: at %.0f%% agreement\n", c.config.Consensus.Threshold*100)
+	}
+	if cluster := c.config.Cluster; cluster != nil {
+		tally := ""
+		if cluster.Tally {
+			tally = ", tallied by cluster"
+		}
+		fmt.Fprintf(c.progress, "Clustering: at %.2f similarity%s\n", cluster.Threshold, tally)
+	}
+	if len(c.config.Rubric) > 0 {
+		var parts []string
+		for _, criterion := range c.config.Rubric {
+			parts = append(parts, fmt.Sprintf("%s %.2f", criterion.Name, criterion.Weight))
+		}
+		fmt.Fprintf(c.progress, "Rubric: %s\n", strings.Join(parts, ", "))
+	}
+	if c.config.Profile != "" {
+		fmt.Fprintf(c.progress, "Profile: %s\n", c.config.Profile)
+	}
+	if len(c.config.Personas) > 0 {
+		names := make([]string, len(c.config.Personas))
+		for i, p := range c.config.Personas {
+			names[i] = p.Name
+		}
+		fmt.Fprintf(c.progress, "Personas: %s\n", strings.Join(names, ", "))
+	}
+	if len(c.session.ContextFiles) > 0 {
+		fmt.Fprintf(c.progress, "Context: %d file(s)\n", len(c.session.ContextFiles))
+	}
+	if len(c.session.Images) > 0 {
+		fmt.Fprintf(c.progress, "Images: %d\n", len(c.session.Images))
+	}
+	fmt.Fprintln(c.progress)
+}
+
+// summarizeTask shortens multi-line or long tasks (e.g. read from stdin) to one header line
+func summarizeTask(task string) string {
+	line, rest, _ := strings.Cut(task, "\n")
+	if len(line) > 100 {
+		line = line[:97] + "..."
+	} else if strings.TrimSpace(rest) != "" {
+		line += " ..."
+	}
+	return line
 }
 
 // printPhase prints a phase status
 func (c *Council) printPhase(phase string) {
-	fmt.Printf("%s... ", phase)
+	fmt.Fprintf(c.progress, "%s... ", phase)
 }
 
 // printPhaseDone prints phase completion
 func (c *Council) printPhaseDone() {
-	fmt.Println("done")
+	fmt.Fprintln(c.progress, "done")
 }
 
 // saveSession saves the session if configured
@@ -193,29 +699,117 @@ func (c *Council) saveSession() error {
 	}
 
 	if path != "" {
-		fmt.Printf("\nSession saved to: %s\n", path)
+		c.savedPath = path
+		fmt.Fprintf(c.progress, "\nSession saved to: %s\n", path)
 	}
 
 	return nil
 }
 
+// warnTruncated reports output that still hit max_tokens after continuation
+func (c *Council) warnTruncated(what string, agentID int) {
+	fmt.Fprintf(os.Stderr, "\nWarning: %s from %s was truncated at max_tokens\n", what, c.session.AgentLabel(agentID))
+}
+
 // PrintVerboseSolution prints a solution in verbose mode
 func (c *Council) PrintVerboseSolution(sol *types.Solution) {
 	if c.config.Verbose {
-		fmt.Printf("\n--- Agent %d Solution ---\n%s\n", sol.AgentID, sol.Content)
+		fmt.Fprintf(c.progress, "\n--- %s Solution ---\n%s\n", c.session.AgentLabel(sol.AgentID), sol.Content)
+	}
+}
+
+// PrintVerboseVerification prints a solution's test results in verbose mode
+func (c *Council) PrintVerboseVerification(sol *types.Solution) {
+	if c.config.Verbose && sol.Verification != nil {
+		fmt.Fprintf(c.progress, "\n--- %s Tests: %s ---\n%s\n", c.session.AgentLabel(sol.AgentID), verificationStatus(sol.Verification), sol.Verification.Output)
+	}
+}
+
+// verificationStatus summarises a verification result in a few words
+func verificationStatus(v *types.Verification) string {
+	switch {
+	case v.Passed:
+		return "passed"
+	case v.Error != "":
+		return "error: " + v.Error
+	default:
+		return fmt.Sprintf("failed (exit %d)", v.ExitCode)
+	}
+}
+
+// PrintVerboseSynthesis prints the synthesis in verbose mode
+func (c *Council) PrintVerboseSynthesis(syn *types.Synthesis) {
+	if c.config.Verbose {
+		fmt.Fprintf(c.progress, "\n--- %s Synthesis ---\n%s\n", c.session.AgentLabel(syn.AgentID), syn.Content)
+	}
+}
+
+// PrintVerboseModeration prints a moderator note in verbose mode
+func (c *Council) PrintVerboseModeration(note *types.ModeratorNote) {
+	if !c.config.Verbose {
+		return
+	}
+	fmt.Fprintf(c.progress, "\n--- Moderator (Round %d) ---\n%s\n", note.Round, note.Summary)
+	for _, d := range note.Disagreements {
+		fmt.Fprintf(c.progress, "Disagreement: %s\n", d)
+	}
+	for _, q := range note.Questions {
+		fmt.Fprintf(c.progress, "Question for %s: %s\n", c.session.AgentLabel(q.AgentID), q.Question)
+	}
+	fmt.Fprintf(c.progress, "Continue: %t (%s)\n", note.Continue, note.Reason)
+}
+
+// PrintVerboseStrawPoll prints a straw poll's votes in verbose mode
+func (c *Council) PrintVerboseStrawPoll(poll *types.StrawPoll) {
+	if !c.config.Verbose {
+		return
+	}
+	fmt.Fprintf(c.progress, "\n--- Straw Poll (Round %d) ---\n", poll.Round)
+	for _, vote := range poll.Votes {
+		fmt.Fprintf(c.progress, "%s: %s\n", c.session.AgentLabel(vote.VoterID), voteSummary(vote))
 	}
 }
 
 // PrintVerboseCritique prints a critique in verbose mode
 func (c *Council) PrintVerboseCritique(crit *types.Critique) {
 	if c.config.Verbose {
-		fmt.Printf("\n--- Agent %d Critique (Round %d) ---\n%s\n", crit.AgentID, crit.Round, crit.Content)
+		fmt.Fprintf(c.progress, "\n--- %s Critique (Round %d) ---\n%s\n", c.session.AgentLabel(crit.AgentID), crit.Round, crit.Content)
+	}
+}
+
+// PrintVerboseJudgment prints a pairwise judgment in verbose mode
+func (c *Council) PrintVerboseJudgment(j *types.PairwiseJudgment) {
+	if c.config.Verbose {
+		fmt.Fprintf(c.progress, "\n--- %s Judgment: %d vs %d ---\nWinner: %d\nReasoning: %s\n", c.session.AgentLabel(j.VoterID), j.A, j.B, j.WinnerID, j.Reasoning)
 	}
 }
 
 // PrintVerboseVote prints a vote in verbose mode
 func (c *Council) PrintVerboseVote(vote *types.Vote) {
-	if c.config.Verbose {
-		fmt.Printf("\n--- Agent %d Vote ---\nRankings: %v\nReasoning: %s\n", vote.VoterID, vote.Rankings, vote.Reasoning)
+	if !c.config.Verbose {
+		return
+	}
+	fmt.Fprintf(c.progress, "\n--- %s Vote ---\n", c.session.AgentLabel(vote.VoterID))
+	if vote.Status == types.VoteFailed {
+		fmt.Fprintf(c.progress, "Failed: %s\n", vote.Error)
+		return
+	}
+	if vote.Status == types.VoteAbstained {
+		fmt.Fprintln(c.progress, "Abstained")
+	} else {
+		fmt.Fprintf(c.progress, "Rankings: %s\n", voteSummary(*vote))
+	}
+	fmt.Fprintf(c.progress, "Reasoning: %s\n", vote.Reasoning)
+}
+
+// voteSummary describes a vote in one line: its rankings and any confidence, or why it ranks nothing
+func voteSummary(vote types.Vote) string {
+	switch {
+	case vote.Status != "":
+		return string(vote.Status)
+	case vote.Confidence > 0:
+		return fmt.Sprintf("%v (confidence %.2f)", vote.Rankings, vote.Confidence)
+	default:
+		return fmt.Sprintf("%v", vote.Rankings)
 	}
 }
@@ -7,29 +7,58 @@ import (
 	"sync"
 
 	"github.com/humzahkiani/council/internal/agent"
+	"github.com/humzahkiani/council/internal/types"
 )
 
-// Discuss runs a discussion round where each agent critiques all solutions
+// Discuss runs a discussion round where each agent critiques all solutions.
+// In a tournament's final, only the finalists take part.
 func (c *Council) Discuss(ctx context.Context, round int) error {
+	critiques, err := c.critiques(ctx, c.discussants(), c.agentSolutions(), round, c.moderation(round-1))
+	if err != nil {
+		return err
+	}
+
+	c.session.Critiques = append(c.session.Critiques, critiques...)
+
+	// Sort critiques by agent ID for consistent ordering
+	sort.Slice(c.session.Critiques, func(i, j int) bool {
+		if c.session.Critiques[i].Round != c.session.Critiques[j].Round {
+			return c.session.Critiques[i].Round < c.session.Critiques[j].Round
+		}
+		return c.session.Critiques[i].AgentID < c.session.Critiques[j].AgentID
+	})
+
+	return nil
+}
+
+// critiques has each critic critique the given solutions in parallel, returning
+// them sorted by agent ID. Each critic also sees the human's critique, if there is one.
+func (c *Council) critiques(ctx context.Context, critics []*agent.Agent, solutions []types.Solution, round int, note *types.ModeratorNote) ([]types.Critique, error) {
+	shared := c.humanCritiques()
+
 	var wg sync.WaitGroup
 	var mu sync.Mutex
-	errChan := make(chan error, len(c.agents))
+	errChan := make(chan error, len(critics))
+	var critiques []types.Critique
 
-	for _, ag := range c.agents {
+	for _, ag := range critics {
 		wg.Add(1)
 		go func(a *agent.Agent) {
 			defer wg.Done()
 
-			critique, err := a.Critique(ctx, c.session.Task, c.session.Solutions, round)
+			critique, err := a.Critique(ctx, c.session.Task, solutions, shared, round, note)
 			if err != nil {
 				errChan <- fmt.Errorf("agent %d: %w", a.ID, err)
 				return
 			}
 
 			mu.Lock()
-			c.session.Critiques = append(c.session.Critiques, *critique)
+			critiques = append(critiques, *critique)
 			mu.Unlock()
 
+			if critique.Truncated {
+				c.warnTruncated("critique", a.ID)
+			}
 			c.PrintVerboseCritique(critique)
 		}(ag)
 	}
@@ -44,16 +73,12 @@ func (c *Council) Discuss(ctx context.Context, round int) error {
 	}
 
 	if len(errs) > 0 {
-		return fmt.Errorf("discussion errors: %v", errs)
+		return nil, fmt.Errorf("discussion errors: %v", errs)
 	}
 
-	// Sort critiques by agent ID for consistent ordering
-	sort.Slice(c.session.Critiques, func(i, j int) bool {
-		if c.session.Critiques[i].Round != c.session.Critiques[j].Round {
-			return c.session.Critiques[i].Round < c.session.Critiques[j].Round
-		}
-		return c.session.Critiques[i].AgentID < c.session.Critiques[j].AgentID
+	sort.Slice(critiques, func(i, j int) bool {
+		return critiques[i].AgentID < critiques[j].AgentID
 	})
 
-	return nil
+	return critiques, nil
 }
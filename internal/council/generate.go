@@ -30,6 +30,9 @@ func (c *Council) Generate(ctx context.Context) error {
 			c.session.Solutions = append(c.session.Solutions, *solution)
 			mu.Unlock()
 
+			if solution.Truncated {
+				c.warnTruncated("solution", a.ID)
+			}
 			c.PrintVerboseSolution(solution)
 		}(ag)
 	}
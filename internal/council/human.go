@@ -0,0 +1,97 @@
+package council
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+// HumanReview is a human participant's critique and ranking of the anonymised solutions
+type HumanReview struct {
+	Critique string
+	Ranking  []int // Indexes into the solutions shown, best first
+}
+
+// Reviewer shows a human the task and the anonymised solutions, labelled A,
+// B, ... in the order given, and returns their review, or nil if they skipped
+type Reviewer func(task string, solutions []string) (*HumanReview, error)
+
+// SetReviewer sets how the human participant is asked for their review, required with a human participant
+func (c *Council) SetReviewer(r Reviewer) {
+	c.reviewer = r
+}
+
+// Review asks the human to critique and rank the solutions after
+// generation. Solutions are shuffled and shown without their authors, so the
+// review is independent of the agents' discussion. The critique is shared
+// with the agents in every discussion round and with the voters, and the vote
+// is added to the agents' votes, straw polls included, with the configured
+// weight. A skipped review leaves the agents to vote alone.
+func (c *Council) Review() error {
+	solutions := c.agentSolutions()
+	order := rand.Perm(len(solutions))
+
+	contents := make([]string, len(solutions))
+	c.session.HumanOrder = make([]int, len(solutions))
+	for i, j := range order {
+		contents[i] = solutions[j].Content
+		c.session.HumanOrder[i] = solutions[j].AgentID
+	}
+
+	review, err := c.reviewer(c.session.Task, contents)
+	if err != nil {
+		return fmt.Errorf("human review failed: %w", err)
+	}
+	if review == nil {
+		fmt.Fprintln(c.progress, "Human review skipped")
+		return nil
+	}
+
+	rankings := make([]int, 0, len(review.Ranking))
+	for _, i := range review.Ranking {
+		if i < 0 || i >= len(solutions) {
+			return fmt.Errorf("human ranking refers to solution %d of %d", i+1, len(solutions))
+		}
+		rankings = append(rankings, c.session.HumanOrder[i])
+	}
+
+	if strings.TrimSpace(review.Critique) != "" {
+		c.session.Critiques = append(c.session.Critiques, types.Critique{
+			AgentID:   types.HumanID,
+			Round:     1,
+			Content:   fmt.Sprintf("%s\n\n(The human reviewed the solutions anonymised as %s.)", strings.TrimSpace(review.Critique), c.humanLegend()),
+			CreatedAt: time.Now(),
+		})
+	}
+
+	c.humanVote = &types.Vote{
+		VoterID:   types.HumanID,
+		Rankings:  rankings,
+		Reasoning: "Human ranking of the anonymised solutions",
+		Weight:    c.config.Human.Weight,
+	}
+	return nil
+}
+
+// humanCritiques returns the human's critique for the discussion, nil if there is none
+func (c *Council) humanCritiques() []types.Critique {
+	var critiques []types.Critique
+	for _, crit := range c.session.Critiques {
+		if crit.AgentID == types.HumanID {
+			critiques = append(critiques, crit)
+		}
+	}
+	return critiques
+}
+
+// humanLegend maps the labels shown to the human back to the agents, e.g. "A = Agent 3, B = Agent 1"
+func (c *Council) humanLegend() string {
+	parts := make([]string, len(c.session.HumanOrder))
+	for i, id := range c.session.HumanOrder {
+		parts[i] = fmt.Sprintf("%s = %s", types.SolutionLabel(i), c.session.AgentLabel(id))
+	}
+	return strings.Join(parts, ", ")
+}
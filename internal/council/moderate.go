@@ -0,0 +1,59 @@
+package council
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+// Moderate has the moderator summarise a discussion round and decide whether
+// another is needed. A moderator that fails twice is skipped for the round
+// rather than failing the run, since the discussion can continue without it.
+func (c *Council) Moderate(ctx context.Context, round int) *types.ModeratorNote {
+	var critiques []types.Critique
+	for _, crit := range c.session.Critiques {
+		if crit.Round == round {
+			critiques = append(critiques, crit)
+		}
+	}
+
+	solutions := c.agentSolutions()
+	finalRound := round == c.config.Rounds
+
+	note, err := c.moderator.Moderate(ctx, c.session.Task, solutions, critiques, round, finalRound)
+	if err != nil {
+		note, err = c.moderator.Moderate(ctx, c.session.Task, solutions, critiques, round, finalRound)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nWarning: moderator failed on round %d: %v\n", round, err)
+		return nil
+	}
+
+	c.session.Moderation = append(c.session.Moderation, *note)
+	c.PrintVerboseModeration(note)
+
+	return note
+}
+
+// moderation returns the moderator's note for a round, nil if there is none
+func (c *Council) moderation(round int) *types.ModeratorNote {
+	for i := range c.session.Moderation {
+		if c.session.Moderation[i].Round == round {
+			return &c.session.Moderation[i]
+		}
+	}
+	return nil
+}
+
+// lastRound returns the last discussion round that ran
+func (c *Council) lastRound() int {
+	round := 0
+	for _, crit := range c.session.Critiques {
+		if crit.Round > round {
+			round = crit.Round
+		}
+	}
+	return round
+}
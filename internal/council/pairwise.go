@@ -0,0 +1,202 @@
+package council
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"github.com/humzahkiani/council/internal/agent"
+	"github.com/humzahkiani/council/internal/types"
+)
+
+const (
+	// btIterations bounds the Bradley-Terry fit, which normally converges in far fewer
+	btIterations = 1000
+	// btTolerance is the largest change in any strength at which the fit has converged
+	btTolerance = 1e-10
+)
+
+// JudgePairs collects pairwise judgments for --voting pairwise. Each agent
+// judges every pair of solutions that doesn't include its own, with the A/B
+// order randomised per judgment to cancel out position bias. A judgment that
+// fails twice is skipped.
+func (c *Council) JudgePairs(ctx context.Context) error {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	voters := c.voters()
+	errChan := make(chan error, len(voters))
+	solutions := c.agentSolutions()
+	critiques := c.session.Critiques
+	note := c.moderation(c.lastRound())
+
+	for _, ag := range voters {
+		wg.Add(1)
+		go func(a *agent.Agent) {
+			defer wg.Done()
+
+			// Each agent judges its pairs one at a time, so at most one request per agent is in flight
+			for i := 0; i < len(solutions); i++ {
+				for j := i + 1; j < len(solutions); j++ {
+					first, second := solutions[i], solutions[j]
+					if first.AgentID == a.ID || second.AgentID == a.ID {
+						continue
+					}
+					if rand.Intn(2) == 1 {
+						first, second = second, first
+					}
+
+					judgment, err := a.Judge(ctx, c.session.Task, first, second, critiques, note)
+					if err != nil {
+						judgment, err = a.Judge(ctx, c.session.Task, first, second, critiques, note)
+					}
+					if err != nil {
+						errChan <- fmt.Errorf("agent %d: judgment of %d vs %d failed after retry: %w", a.ID, first.AgentID, second.AgentID, err)
+						continue
+					}
+
+					mu.Lock()
+					c.session.Judgments = append(c.session.Judgments, *judgment)
+					mu.Unlock()
+
+					c.PrintVerboseJudgment(judgment)
+				}
+			}
+		}(ag)
+	}
+
+	wg.Wait()
+	close(errChan)
+
+	// Log errors but don't fail - we proceed with whatever judgments we got
+	for err := range errChan {
+		if c.config.Verbose {
+			fmt.Fprintf(c.progress, "Warning: %v\n", err)
+		}
+	}
+
+	// Sort judgments by voter, then pair, for consistent ordering
+	sort.Slice(c.session.Judgments, func(i, j int) bool {
+		x, y := c.session.Judgments[i], c.session.Judgments[j]
+		if x.VoterID != y.VoterID {
+			return x.VoterID < y.VoterID
+		}
+		if lo(x) != lo(y) {
+			return lo(x) < lo(y)
+		}
+		return hi(x) < hi(y)
+	})
+
+	return nil
+}
+
+// tallyPairwise scores each candidate by its pairwise wins and picks the
+// winner by Bradley-Terry strength. Every pair is judged by the same number of
+// voters, so the two orders agree unless judgments failed.
+func (c *Council) tallyPairwise() {
+	candidates := c.candidates()
+
+	c.session.Scores = make(map[int]int)
+	for _, id := range candidates {
+		c.session.Scores[id] = 0
+	}
+	for _, j := range c.session.Judgments {
+		c.session.Scores[j.WinnerID]++
+	}
+
+	c.session.Strengths = bradleyTerry(candidates, c.session.Judgments)
+
+	best := 0.0
+	for _, s := range c.session.Strengths {
+		best = math.Max(best, s)
+	}
+	var winners []int
+	for _, id := range candidates {
+		if best-c.session.Strengths[id] < 1e-6 {
+			winners = append(winners, id)
+		}
+	}
+	c.setWinners(winners)
+}
+
+// bradleyTerry fits Bradley-Terry strengths to the judgments with Hunter's MM
+// algorithm, normalised to sum to 1. Each pair of candidates also gets one
+// virtual drawn game, which keeps the strength of a candidate that never won
+// above zero and the fit defined when judgments are missing.
+func bradleyTerry(candidates []int, judgments []types.PairwiseJudgment) map[int]float64 {
+	n := len(candidates)
+	index := make(map[int]int, n)
+	for i, id := range candidates {
+		index[id] = i
+	}
+
+	wins := make([]float64, n)
+	games := make([][]float64, n)
+	for i := range games {
+		games[i] = make([]float64, n)
+		for j := range games[i] {
+			if i != j {
+				games[i][j] = 1
+			}
+		}
+		wins[i] = 0.5 * float64(n-1)
+	}
+	for _, j := range judgments {
+		a, okA := index[j.A]
+		b, okB := index[j.B]
+		w, okW := index[j.WinnerID]
+		if !okA || !okB || !okW {
+			continue
+		}
+		games[a][b]++
+		games[b][a]++
+		wins[w]++
+	}
+
+	p := make([]float64, n)
+	for i := range p {
+		p[i] = 1 / float64(n)
+	}
+	for iter := 0; iter < btIterations; iter++ {
+		next := make([]float64, n)
+		sum := 0.0
+		for i := range p {
+			denom := 0.0
+			for j := range p {
+				if i != j {
+					denom += games[i][j] / (p[i] + p[j])
+				}
+			}
+			next[i] = wins[i] / denom
+			sum += next[i]
+		}
+
+		change := 0.0
+		for i := range next {
+			next[i] /= sum
+			change = math.Max(change, math.Abs(next[i]-p[i]))
+		}
+		p = next
+		if change < btTolerance {
+			break
+		}
+	}
+
+	strengths := make(map[int]float64, n)
+	for i, id := range candidates {
+		strengths[id] = p[i]
+	}
+	return strengths
+}
+
+// lo returns the lower agent ID of a judged pair
+func lo(j types.PairwiseJudgment) int {
+	return min(j.A, j.B)
+}
+
+// hi returns the higher agent ID of a judged pair
+func hi(j types.PairwiseJudgment) int {
+	return max(j.A, j.B)
+}
@@ -0,0 +1,122 @@
+package council
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+// beats returns one judgment per pair, won by the first ID
+func beats(pairs ...[2]int) []types.PairwiseJudgment {
+	var judgments []types.PairwiseJudgment
+	for _, p := range pairs {
+		judgments = append(judgments, types.PairwiseJudgment{A: p[0], B: p[1], WinnerID: p[0]})
+	}
+	return judgments
+}
+
+func TestBradleyTerry(t *testing.T) {
+	tests := []struct {
+		name      string
+		judgments []types.PairwiseJudgment
+		order     []int // Strongest first; nil when every strength is equal
+	}{
+		{
+			name:      "no judgments",
+			judgments: nil,
+		},
+		{
+			name:      "cycle",
+			judgments: beats([2]int{1, 2}, [2]int{2, 3}, [2]int{3, 1}),
+		},
+		{
+			name:      "unanimous",
+			judgments: beats([2]int{3, 1}, [2]int{3, 2}, [2]int{1, 2}, [2]int{3, 1}, [2]int{3, 2}, [2]int{1, 2}),
+			order:     []int{3, 1, 2},
+		},
+		{
+			name: "judgments outside the candidates are ignored",
+			judgments: append(beats([2]int{2, 1}, [2]int{2, 3}, [2]int{1, 3}),
+				types.PairwiseJudgment{A: 1, B: 9, WinnerID: 9}),
+			order: []int{2, 1, 3},
+		},
+	}
+
+	candidates := []int{1, 2, 3}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strengths := bradleyTerry(candidates, tt.judgments)
+
+			sum := 0.0
+			for _, id := range candidates {
+				if strengths[id] <= 0 {
+					t.Errorf("strength of %d = %g, want positive", id, strengths[id])
+				}
+				sum += strengths[id]
+			}
+			if !near(sum, 1) {
+				t.Errorf("strengths sum to %g, want 1", sum)
+			}
+
+			if tt.order == nil {
+				for _, id := range candidates {
+					if math.Abs(strengths[id]-1.0/3) > 1e-6 {
+						t.Errorf("strength of %d = %g, want 1/3", id, strengths[id])
+					}
+				}
+				return
+			}
+			for i := 1; i < len(tt.order); i++ {
+				if strengths[tt.order[i-1]] <= strengths[tt.order[i]] {
+					t.Errorf("strengths %v not in order %v", strengths, tt.order)
+				}
+			}
+		})
+	}
+}
+
+func TestTallyPairwise(t *testing.T) {
+	tests := []struct {
+		name      string
+		judgments []types.PairwiseJudgment
+		winner    int   // 0 for a tie
+		tied      []int // With a tie
+		wins      map[int]int
+	}{
+		{
+			name:      "cycle ties every candidate",
+			judgments: beats([2]int{1, 2}, [2]int{2, 3}, [2]int{3, 1}),
+			tied:      []int{1, 2, 3},
+			wins:      map[int]int{1: 1, 2: 1, 3: 1},
+		},
+		{
+			name:      "unanimous winner",
+			judgments: beats([2]int{2, 1}, [2]int{2, 3}, [2]int{1, 3}),
+			winner:    2,
+			wins:      map[int]int{1: 1, 2: 2, 3: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testCouncil(3, &types.Config{Voting: types.VotingPairwise})
+			c.session.Judgments = tt.judgments
+			c.Tally()
+
+			if !reflect.DeepEqual(c.session.Scores, tt.wins) {
+				t.Errorf("wins = %v, want %v", c.session.Scores, tt.wins)
+			}
+			if tt.winner != 0 {
+				if c.session.WinnerID == nil || *c.session.WinnerID != tt.winner {
+					t.Errorf("winner = %v (tied %v), want %d", c.session.WinnerID, c.session.TiedAgents, tt.winner)
+				}
+				return
+			}
+			if !c.session.IsTie || !reflect.DeepEqual(c.session.TiedAgents, tt.tied) {
+				t.Errorf("tied = %v (is tie %v), want %v", c.session.TiedAgents, c.session.IsTie, tt.tied)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package council
+
+import (
+	"github.com/humzahkiani/council/internal/types"
+)
+
+// tallyRubric averages each criterion's scores across the voters who gave
+// one and weights the means into a total per solution. Rubric totals are
+// reported alongside the ranking; the Borda count still decides the winner.
+func (c *Council) tallyRubric() {
+	c.session.RubricScores, c.session.RubricTotals = rubricScores(c.session.Votes, c.config.Rubric)
+}
+
+// rubricScores returns the mean score per criterion and the weighted mean over
+// the scored criteria for each scored solution
+func rubricScores(votes []types.Vote, rubric []types.Criterion) (map[int]map[string]float64, map[int]float64) {
+	sums := make(map[int]map[string]float64)
+	counts := make(map[int]map[string]int)
+	for _, vote := range votes {
+		for agentID, byCriterion := range vote.Scores {
+			if sums[agentID] == nil {
+				sums[agentID] = make(map[string]float64)
+				counts[agentID] = make(map[string]int)
+			}
+			for name, score := range byCriterion {
+				sums[agentID][name] += score
+				counts[agentID][name]++
+			}
+		}
+	}
+
+	// A criterion no voter scored is left out of the total, and the total is
+	// divided by the weight of the criteria that were scored, so a solution
+	// isn't marked down for missing scores
+	means := make(map[int]map[string]float64)
+	totals := make(map[int]float64)
+	for agentID, byCriterion := range sums {
+		means[agentID] = make(map[string]float64)
+		scored := 0.0
+		for _, criterion := range rubric {
+			n := counts[agentID][criterion.Name]
+			if n == 0 {
+				continue
+			}
+			mean := byCriterion[criterion.Name] / float64(n)
+			means[agentID][criterion.Name] = mean
+			totals[agentID] += criterion.Weight * mean
+			scored += criterion.Weight
+		}
+		if scored > 0 {
+			totals[agentID] /= scored
+		}
+	}
+
+	return means, totals
+}
@@ -0,0 +1,68 @@
+package council
+
+import (
+	"testing"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+func TestRubricScores(t *testing.T) {
+	rubric := []types.Criterion{
+		{Name: "correctness", Weight: 0.5},
+		{Name: "clarity", Weight: 0.25},
+		{Name: "performance", Weight: 0.25},
+	}
+
+	tests := []struct {
+		name  string
+		votes []types.Vote
+		want  map[int]float64
+	}{
+		{
+			name: "every criterion scored",
+			votes: []types.Vote{
+				{VoterID: 1, Scores: map[int]map[string]float64{2: {"correctness": 8, "clarity": 4, "performance": 6}}},
+			},
+			want: map[int]float64{2: 6.5},
+		},
+		{
+			name: "means across voters",
+			votes: []types.Vote{
+				{VoterID: 1, Scores: map[int]map[string]float64{2: {"correctness": 10, "clarity": 6, "performance": 6}}},
+				{VoterID: 3, Scores: map[int]map[string]float64{2: {"correctness": 6, "clarity": 6, "performance": 6}}},
+			},
+			want: map[int]float64{2: 7},
+		},
+		{
+			name: "unscored criterion doesn't lower the total",
+			votes: []types.Vote{
+				{VoterID: 1, Scores: map[int]map[string]float64{
+					2: {"correctness": 8, "clarity": 8, "performance": 8},
+					3: {"correctness": 8, "clarity": 8},
+				}},
+			},
+			want: map[int]float64{2: 8, 3: 8},
+		},
+		{
+			name: "unknown criteria ignored",
+			votes: []types.Vote{
+				{VoterID: 1, Scores: map[int]map[string]float64{2: {"correctness": 4, "style": 10}}},
+			},
+			want: map[int]float64{2: 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, totals := rubricScores(tt.votes, rubric)
+			if len(totals) != len(tt.want) {
+				t.Fatalf("totals = %v, want %v", totals, tt.want)
+			}
+			for id, want := range tt.want {
+				if !near(totals[id], want) {
+					t.Errorf("total for %d = %g, want %g", id, totals[id], want)
+				}
+			}
+		})
+	}
+}
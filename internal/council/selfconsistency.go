@@ -0,0 +1,67 @@
+package council
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/humzahkiani/council/internal/answer"
+)
+
+// SelfConsistency decides the run by majority of the solutions' final
+// answers, in place of discussion and voting. Each agent scores the number of
+// solutions giving its answer, and the first solution with the most common
+// answer wins. With no single most common answer, the first solution giving
+// each of the tied answers is tied; with no answers at all, every solution is.
+func (c *Council) SelfConsistency() {
+	solutions := c.session.Solutions
+
+	groups := make(map[string][]int) // Normalized answer -> AgentIDs, in solution order
+	c.session.Answers = make(map[int]string)
+	for _, sol := range solutions {
+		extracted := answer.Extract(sol.Content)
+		c.session.Answers[sol.AgentID] = extracted
+		if normalized := answer.Normalize(extracted); normalized != "" {
+			groups[normalized] = append(groups[normalized], sol.AgentID)
+		}
+	}
+
+	for _, sol := range solutions {
+		c.session.Scores[sol.AgentID] = len(groups[answer.Normalize(c.session.Answers[sol.AgentID])])
+	}
+
+	// The first solution of each most common answer; just one is a majority
+	most := 0
+	for _, ids := range groups {
+		most = max(most, len(ids))
+	}
+	var winners []int
+	for _, ids := range groups {
+		if len(ids) == most {
+			winners = append(winners, ids[0])
+		}
+	}
+	if len(winners) == 0 {
+		for _, sol := range solutions {
+			winners = append(winners, sol.AgentID)
+		}
+	}
+	sort.Ints(winners)
+	c.setWinners(winners)
+}
+
+// answerSummary describes the majority answer for the phase status line
+func (c *Council) answerSummary() string {
+	if c.session.WinnerID == nil {
+		return fmt.Sprintf("no majority answer among %d solutions", len(c.session.Solutions))
+	}
+	id := *c.session.WinnerID
+	return fmt.Sprintf("%s given by %d of %d solutions", answerText(c.session.Answers[id]), c.session.Scores[id], len(c.session.Solutions))
+}
+
+// answerText quotes an extracted answer for display, or notes that there was none
+func answerText(extracted string) string {
+	if extracted == "" {
+		return "no answer found"
+	}
+	return fmt.Sprintf("%q", extracted)
+}
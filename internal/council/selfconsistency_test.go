@@ -0,0 +1,73 @@
+package council
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+func TestSelfConsistency(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents []string
+		winner   int   // 0 for a tie
+		tied     []int // With a tie
+		scores   map[int]int
+	}{
+		{
+			name:     "majority answer wins with its first solution",
+			contents: []string{"Final Answer: 41", "Final Answer: 42", "Final Answer: 42."},
+			winner:   2,
+			scores:   map[int]int{1: 1, 2: 2, 3: 2},
+		},
+		{
+			name:     "tie between answers",
+			contents: []string{"Final Answer: Paris", "Final Answer: Lyon", "Final Answer: lyon", "Final Answer: paris", "Final Answer: Nice"},
+			tied:     []int{1, 2},
+			scores:   map[int]int{1: 2, 2: 2, 3: 2, 4: 2, 5: 1},
+		},
+		{
+			name:     "no answers ties every solution",
+			contents: []string{"", "```\n```"},
+			tied:     []int{1, 2},
+			scores:   map[int]int{1: 0, 2: 0},
+		},
+		{
+			name:     "one answer against none",
+			contents: []string{"```\n```", "Final Answer: yes"},
+			winner:   2,
+			scores:   map[int]int{1: 0, 2: 1},
+		},
+		{
+			name:     "solutions without an answer don't count",
+			contents: []string{"", "Final Answer: 7", "```\n```"},
+			winner:   2,
+			scores:   map[int]int{1: 0, 2: 1, 3: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testCouncil(len(tt.contents), &types.Config{Mode: types.ModeSelfConsistency})
+			for i, content := range tt.contents {
+				c.session.Solutions = append(c.session.Solutions, types.Solution{AgentID: i + 1, Content: content})
+			}
+
+			c.SelfConsistency()
+
+			if !reflect.DeepEqual(c.session.Scores, tt.scores) {
+				t.Errorf("scores = %v, want %v", c.session.Scores, tt.scores)
+			}
+			if tt.winner != 0 {
+				if c.session.WinnerID == nil || *c.session.WinnerID != tt.winner {
+					t.Errorf("winner = %v (tied %v), want %d", c.session.WinnerID, c.session.TiedAgents, tt.winner)
+				}
+				return
+			}
+			if !c.session.IsTie || !reflect.DeepEqual(c.session.TiedAgents, tt.tied) {
+				t.Errorf("tied = %v (is tie %v), want %v", c.session.TiedAgents, c.session.IsTie, tt.tied)
+			}
+		})
+	}
+}
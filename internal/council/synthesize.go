@@ -0,0 +1,72 @@
+package council
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/humzahkiani/council/internal/agent"
+	"github.com/humzahkiani/council/internal/types"
+)
+
+// Synthesize has one agent merge the top-ranked solutions into a final answer
+func (c *Council) Synthesize(ctx context.Context) error {
+	ranked := c.rankedSolutions()
+	if len(ranked) == 0 {
+		return fmt.Errorf("no solutions to synthesize")
+	}
+
+	topK := c.config.Synthesis.TopK
+	if topK <= 0 || topK > len(ranked) {
+		topK = len(ranked)
+	}
+	top := ranked[:topK]
+
+	synthesizer := c.synthesizer(ranked)
+	synthesis, err := synthesizer.Synthesize(ctx, c.session.Task, top, c.session.Critiques, c.session.Standings())
+	if err != nil {
+		return fmt.Errorf("agent %d: %w", synthesizer.ID, err)
+	}
+
+	c.session.Synthesis = synthesis
+	if synthesis.Truncated {
+		c.warnTruncated("synthesis", synthesizer.ID)
+	}
+	c.PrintVerboseSynthesis(synthesis)
+
+	return nil
+}
+
+// rankedSolutions returns the solutions as agents see them, in the order of the
+// session's standings; solutions outside the vote, such as a tournament's
+// knocked-out ones, come last
+func (c *Council) rankedSolutions() []types.Solution {
+	place := make(map[int]int)
+	for i, st := range c.session.Standings() {
+		place[st.AgentID] = i + 1
+	}
+	rank := func(id int) int {
+		if p, ok := place[id]; ok {
+			return p
+		}
+		return len(place) + 1
+	}
+
+	ranked := c.agentSolutions()
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return rank(ranked[i].AgentID) < rank(ranked[j].AgentID)
+	})
+	return ranked
+}
+
+// synthesizer returns the configured agent, else the winner, else the top-ranked agent
+func (c *Council) synthesizer(ranked []types.Solution) *agent.Agent {
+	id := c.config.Synthesis.Synthesizer
+	if id == 0 {
+		id = ranked[0].AgentID
+		if c.session.WinnerID != nil {
+			id = *c.session.WinnerID
+		}
+	}
+	return c.agents[id-1]
+}
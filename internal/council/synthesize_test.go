@@ -0,0 +1,57 @@
+package council
+
+import (
+	"testing"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+func TestRankedSolutionsFollowTally(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  types.Config
+		weights map[int]float64
+		votes   []types.Vote
+	}{
+		{
+			name:    "weighted votes overturn the raw points",
+			weights: map[int]float64{4: 4},
+			votes: []types.Vote{
+				{VoterID: 1, Rankings: []int{2, 3}},
+				{VoterID: 2, Rankings: []int{1, 3}},
+				{VoterID: 3, Rankings: []int{1, 2}},
+				{VoterID: 4, Rankings: []int{2, 1, 3}},
+			},
+		},
+		{
+			name:   "confidence overturns the raw points",
+			config: types.Config{WeighConfidence: true},
+			votes: []types.Vote{
+				{VoterID: 1, Rankings: []int{3, 2}, Confidence: 1},
+				{VoterID: 2, Rankings: []int{3, 1}, Confidence: 1},
+				{VoterID: 3, Rankings: []int{2, 1}, Confidence: 0.1},
+				{VoterID: 4, Rankings: []int{2, 1, 3}, Confidence: 0.1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testCouncil(4, &tt.config)
+			for id := 1; id <= 4; id++ {
+				c.session.Solutions = append(c.session.Solutions, types.Solution{AgentID: id})
+			}
+			c.session.Weights = tt.weights
+			c.session.Votes = tt.votes
+			c.Tally()
+
+			if c.session.WinnerID == nil {
+				t.Fatalf("tally tied between %v", c.session.TiedAgents)
+			}
+			ranked := c.rankedSolutions()
+			if ranked[0].AgentID != *c.session.WinnerID {
+				t.Errorf("top-ranked solution is %d, want the winner %d", ranked[0].AgentID, *c.session.WinnerID)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package council
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/humzahkiani/council/internal/agent"
+	"github.com/humzahkiani/council/internal/types"
+)
+
+// GroupStage runs the first stage of a tournament. Agents are split into
+// groups that each discuss and vote on their own solutions as a mini-council,
+// all groups at once. Each group's winner, or every tied agent on a tie,
+// advances to the final, which runs as a normal council over the finalists.
+func (c *Council) GroupStage(ctx context.Context) error {
+	ids := make([]int, len(c.agents))
+	for i, ag := range c.agents {
+		ids[i] = ag.ID
+	}
+
+	bracket := &types.Bracket{GroupSize: c.config.GroupSize}
+	for i, members := range splitGroups(ids, c.config.GroupSize) {
+		bracket.Groups = append(bracket.Groups, types.Group{ID: i + 1, AgentIDs: members})
+	}
+	c.session.Bracket = bracket
+
+	for round := 1; round <= c.config.Rounds; round++ {
+		c.printPhase(fmt.Sprintf("Group stage round %d (%d groups)", round, len(bracket.Groups)))
+		err := c.eachGroup(func(g *types.Group) error {
+			critiques, err := c.critiques(ctx, c.members(g), c.groupSolutions(g), round, nil)
+			if err != nil {
+				return fmt.Errorf("group %d: %w", g.ID, err)
+			}
+			g.Critiques = append(g.Critiques, critiques...)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("group stage failed: %w", err)
+		}
+		c.printPhaseDone()
+		if err := c.checkBudget(types.PhaseDiscuss, round); err != nil {
+			return err
+		}
+	}
+
+	c.printPhase("Group voting")
+	c.eachGroup(func(g *types.Group) error {
+		g.Votes = c.collectVotes(ctx, c.members(g), c.groupSolutions(g), g.Critiques, nil)
+		g.Scores = borda(g.Votes, g.AgentIDs)
+		g.WinnerIDs = leaders(g.Scores)
+		return nil
+	})
+	c.printPhaseDone()
+
+	for _, g := range bracket.Groups {
+		bracket.Finalists = append(bracket.Finalists, g.WinnerIDs...)
+		fmt.Fprintf(c.progress, "  Group %d (Agents %s): %s\n", g.ID, joinIDs(g.AgentIDs), c.advanced(g))
+	}
+	sort.Ints(bracket.Finalists)
+	c.finalists = bracket.Finalists
+
+	return c.checkBudget(types.PhaseVote, 0)
+}
+
+// splitGroups divides ids into contiguous groups of about size agents. Group
+// sizes differ by at most one, and no group is smaller than MinGroupSize.
+func splitGroups(ids []int, size int) [][]int {
+	n := len(ids)
+	count := (n + size - 1) / size
+	for count > 1 && n/count < types.MinGroupSize {
+		count--
+	}
+
+	groups := make([][]int, 0, count)
+	start := 0
+	for i := 0; i < count; i++ {
+		groupSize := n / count
+		if i < n%count {
+			groupSize++
+		}
+		groups = append(groups, ids[start:start+groupSize])
+		start += groupSize
+	}
+	return groups
+}
+
+// eachGroup runs fn for every group in parallel, returning the first error
+func (c *Council) eachGroup(fn func(g *types.Group) error) error {
+	var wg sync.WaitGroup
+	groups := c.session.Bracket.Groups
+	errChan := make(chan error, len(groups))
+
+	for i := range groups {
+		wg.Add(1)
+		go func(g *types.Group) {
+			defer wg.Done()
+			if err := fn(g); err != nil {
+				errChan <- err
+			}
+		}(&groups[i])
+	}
+
+	wg.Wait()
+	close(errChan)
+
+	return <-errChan
+}
+
+// members returns a group's agents
+func (c *Council) members(g *types.Group) []*agent.Agent {
+	agents := make([]*agent.Agent, len(g.AgentIDs))
+	for i, id := range g.AgentIDs {
+		agents[i] = c.agents[id-1]
+	}
+	return agents
+}
+
+// groupSolutions returns a group's solutions as its agents should see them
+func (c *Council) groupSolutions(g *types.Group) []types.Solution {
+	var solutions []types.Solution
+	for _, sol := range c.agentSolutions() {
+		if contains(g.AgentIDs, sol.AgentID) {
+			solutions = append(solutions, sol)
+		}
+	}
+	return solutions
+}
+
+// advanced describes who advanced from a group
+func (c *Council) advanced(g types.Group) string {
+	labels := make([]string, len(g.WinnerIDs))
+	for i, id := range g.WinnerIDs {
+		labels[i] = fmt.Sprintf("%s (%d points)", c.session.AgentLabel(id), g.Scores[id])
+	}
+	if len(labels) > 1 {
+		return "tie, " + strings.Join(labels, ", ") + " advance"
+	}
+	return labels[0] + " advances"
+}
+
+// candidates returns the IDs of the agents whose solutions are still in contention
+func (c *Council) candidates() []int {
+	if c.finalists != nil {
+		return c.finalists
+	}
+	ids := make([]int, len(c.agents))
+	for i, ag := range c.agents {
+		ids[i] = ag.ID
+	}
+	return ids
+}
+
+// discussants returns the agents taking part in discussion: the judges if
+// there are any, the finalists in a tournament, else everyone
+func (c *Council) discussants() []*agent.Agent {
+	if len(c.judges) > 0 {
+		return c.judges
+	}
+	if c.finalists == nil {
+		return c.agents
+	}
+	agents := make([]*agent.Agent, len(c.finalists))
+	for i, id := range c.finalists {
+		agents[i] = c.agents[id-1]
+	}
+	return agents
+}
+
+// joinIDs formats agent IDs as "1, 2, 3"
+func joinIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = fmt.Sprint(id)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// contains reports whether ids includes id
+func contains(ids []int, id int) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
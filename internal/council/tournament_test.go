@@ -0,0 +1,176 @@
+package council
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/humzahkiani/council/internal/agent"
+	"github.com/humzahkiani/council/internal/types"
+)
+
+var (
+	voterName     = regexp.MustCompile(`You are Agent (\d+)`)
+	solutionLabel = regexp.MustCompile(`### Solution (\d+) \(`)
+)
+
+// stubAPI serves the messages endpoint for a council's agents. Critiques get a
+// fixed reply; each voter ranks the other solutions it was shown from the
+// highest agent ID down, except that voters for which fail is true get an error.
+func stubAPI(t *testing.T, fail func(voter int) bool) *agent.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			System   string          `json:"system"`
+			Messages []agent.Message `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("bad request: %v", err)
+		}
+
+		text := "Looks reasonable."
+		if strings.Contains(req.System, "Respond with a JSON object") {
+			m := voterName.FindStringSubmatch(req.System)
+			if m == nil {
+				t.Errorf("vote request without a voter:\n%s", req.System)
+				return
+			}
+			voter, _ := strconv.Atoi(m[1])
+			if fail(voter) {
+				w.WriteHeader(http.StatusBadRequest)
+				io.WriteString(w, `{"type": "error", "error": {"type": "invalid_request_error", "message": "stub failure"}}`)
+				return
+			}
+
+			var rankings []int
+			for _, m := range solutionLabel.FindAllStringSubmatch(req.Messages[0].Content[0].Text, -1) {
+				if id, _ := strconv.Atoi(m[1]); id != voter {
+					rankings = append(rankings, id)
+				}
+			}
+			sort.Sort(sort.Reverse(sort.IntSlice(rankings)))
+			vote, _ := json.Marshal(map[string]any{"rankings": rankings, "reasoning": "stub"})
+			text = string(vote)
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"content":     []map[string]string{{"type": "text", "text": text}},
+			"stop_reason": "end_turn",
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("ANTHROPIC_BASE_URL", server.URL)
+	return agent.NewClient("key", "model")
+}
+
+func TestGroupStage(t *testing.T) {
+	tests := []struct {
+		name      string
+		fail      func(voter int) bool
+		winners   [][]int
+		finalists []int
+	}{
+		{
+			name:      "each group's winner advances",
+			fail:      func(int) bool { return false },
+			winners:   [][]int{{3}, {6}},
+			finalists: []int{3, 6},
+		},
+		{
+			name:      "a failed group vote advances the whole group",
+			fail:      func(voter int) bool { return voter > 3 },
+			winners:   [][]int{{3}, {4, 5, 6}},
+			finalists: []int{3, 4, 5, 6},
+		},
+		{
+			name:      "a partly failed group vote still counts",
+			fail:      func(voter int) bool { return voter == 6 },
+			winners:   [][]int{{3}, {6}},
+			finalists: []int{3, 6},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := stubAPI(t, tt.fail)
+			c := testCouncil(6, &types.Config{Rounds: 1, GroupSize: 3, Mode: types.ModeTournament})
+			c.client = client
+			c.progress = io.Discard
+			for i, ag := range c.agents {
+				c.agents[i] = agent.New(ag.ID, ag.Total, client)
+				c.session.Solutions = append(c.session.Solutions, types.Solution{AgentID: ag.ID, Content: "solution " + strconv.Itoa(ag.ID)})
+			}
+
+			if err := c.GroupStage(t.Context()); err != nil {
+				t.Fatalf("GroupStage: %v", err)
+			}
+
+			bracket := c.session.Bracket
+			var winners [][]int
+			for _, g := range bracket.Groups {
+				winners = append(winners, g.WinnerIDs)
+				if len(g.Critiques) != len(g.AgentIDs) {
+					t.Errorf("group %d has %d critiques, want one per member", g.ID, len(g.Critiques))
+				}
+				for _, vote := range g.Votes {
+					if failed := vote.Status == types.VoteFailed; failed != tt.fail(vote.VoterID) {
+						t.Errorf("vote of %d failed = %v, want %v", vote.VoterID, failed, !failed)
+					}
+				}
+			}
+			if !reflect.DeepEqual(winners, tt.winners) {
+				t.Errorf("group winners = %v, want %v", winners, tt.winners)
+			}
+			if !reflect.DeepEqual(bracket.Finalists, tt.finalists) || !reflect.DeepEqual(c.candidates(), tt.finalists) {
+				t.Errorf("finalists = %v (candidates %v), want %v", bracket.Finalists, c.candidates(), tt.finalists)
+			}
+		})
+	}
+}
+
+func TestSplitGroups(t *testing.T) {
+	tests := []struct {
+		n, size int
+		want    []int // Group sizes
+	}{
+		{5, 3, []int{5}},
+		{5, 4, []int{5}},
+		{6, 3, []int{3, 3}},
+		{7, 3, []int{4, 3}},
+		{7, 4, []int{4, 3}},
+		{7, 5, []int{4, 3}},
+		{12, 3, []int{3, 3, 3, 3}},
+		{12, 4, []int{4, 4, 4}},
+		{12, 5, []int{4, 4, 4}},
+		{12, 7, []int{6, 6}},
+	}
+
+	for _, tt := range tests {
+		ids := make([]int, tt.n)
+		for i := range ids {
+			ids[i] = i + 1
+		}
+
+		groups := splitGroups(ids, tt.size)
+
+		var sizes, all []int
+		for _, g := range groups {
+			sizes = append(sizes, len(g))
+			all = append(all, g...)
+		}
+		if !reflect.DeepEqual(sizes, tt.want) {
+			t.Errorf("splitGroups(%d agents, size %d) sizes = %v, want %v", tt.n, tt.size, sizes, tt.want)
+		}
+		if !reflect.DeepEqual(all, ids) {
+			t.Errorf("splitGroups(%d agents, size %d) = %v, want every agent once, in order", tt.n, tt.size, groups)
+		}
+	}
+}
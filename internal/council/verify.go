@@ -0,0 +1,56 @@
+package council
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/humzahkiani/council/internal/types"
+	"github.com/humzahkiani/council/internal/verify"
+)
+
+// Verify runs every solution's code blocks against the test command in parallel.
+// Failures to run are recorded on the solution rather than failing the phase.
+func (c *Council) Verify(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for i := range c.session.Solutions {
+		wg.Add(1)
+		go func(sol *types.Solution) {
+			defer wg.Done()
+			sol.Verification = verify.Run(ctx, c.config.Verify, sol.Content)
+			c.PrintVerboseVerification(sol)
+		}(&c.session.Solutions[i])
+	}
+
+	wg.Wait()
+}
+
+// verificationSummary counts passing solutions for the phase status line
+func (c *Council) verificationSummary() string {
+	passed := 0
+	for _, sol := range c.session.Solutions {
+		if sol.Verification != nil && sol.Verification.Passed {
+			passed++
+		}
+	}
+	return fmt.Sprintf("%d/%d passed", passed, len(c.session.Solutions))
+}
+
+// agentSolutions returns the solutions still in contention as agents should
+// see them: with test results only when the run is configured to show them
+func (c *Council) agentSolutions() []types.Solution {
+	show := c.config.Verify != nil && c.config.Verify.Show
+
+	var solutions []types.Solution
+	for _, sol := range c.session.Solutions {
+		if c.finalists != nil && !contains(c.finalists, sol.AgentID) {
+			continue
+		}
+		if !show {
+			sol.Verification = nil
+		}
+		solutions = append(solutions, sol)
+	}
+	return solutions
+}
@@ -10,37 +10,64 @@ import (
 	"github.com/humzahkiani/council/internal/types"
 )
 
-// Vote collects ranked votes from all agents
+// Vote collects ranked votes from all agents, or pairwise judgments with
+// --voting pairwise. In a tournament's final, every agent votes on the finalists.
+// With separate judges, only the judges vote. A human's vote is added last.
 func (c *Council) Vote(ctx context.Context) error {
+	if c.config.Voting == types.VotingPairwise {
+		return c.JudgePairs(ctx)
+	}
+
+	c.session.Votes = c.collectVotes(ctx, c.voters(), c.agentSolutions(), c.session.Critiques, c.moderation(c.lastRound()))
+	for i := range c.session.Votes {
+		c.PrintVerboseVote(&c.session.Votes[i])
+	}
+	if c.humanVote != nil {
+		c.session.Votes = append(c.session.Votes, *c.humanVote)
+	}
+	return nil
+}
+
+// voters returns the agents who vote: the judges if there are any, else every agent
+func (c *Council) voters() []*agent.Agent {
+	if len(c.judges) > 0 {
+		return c.judges
+	}
+	return c.agents
+}
+
+// collectVotes asks each voter to rank the solutions given the critiques,
+// sorted by voter ID. A voter whose vote fails twice gets a failed vote,
+// recording the error; abstentions come back from the voter as they are.
+func (c *Council) collectVotes(ctx context.Context, voters []*agent.Agent, solutions []types.Solution, critiques []types.Critique, note *types.ModeratorNote) []types.Vote {
 	var wg sync.WaitGroup
 	var mu sync.Mutex
-	errChan := make(chan error, len(c.agents))
+	errChan := make(chan error, len(voters))
+	var votes []types.Vote
 
-	for _, ag := range c.agents {
+	for _, ag := range voters {
 		wg.Add(1)
 		go func(a *agent.Agent) {
 			defer wg.Done()
 
-			vote, err := a.Vote(ctx, c.session.Task, c.session.Solutions, c.session.Critiques)
+			vote, err := a.Vote(ctx, c.session.Task, solutions, critiques, note)
 			if err != nil {
-				// Per spec: re-prompt agent once, then use empty vote
-				vote, err = a.Vote(ctx, c.session.Task, c.session.Solutions, c.session.Critiques)
+				// Per spec: re-prompt agent once, then record the failure
+				vote, err = a.Vote(ctx, c.session.Task, solutions, critiques, note)
 				if err != nil {
-					// Use empty vote if retry fails
 					vote = &types.Vote{
-						VoterID:   a.ID,
-						Rankings:  []int{},
-						Reasoning: "Vote failed to parse",
+						VoterID:  a.ID,
+						Rankings: []int{},
+						Status:   types.VoteFailed,
+						Error:    err.Error(),
 					}
 					errChan <- fmt.Errorf("agent %d: vote failed after retry: %w", a.ID, err)
 				}
 			}
 
 			mu.Lock()
-			c.session.Votes = append(c.session.Votes, *vote)
+			votes = append(votes, *vote)
 			mu.Unlock()
-
-			c.PrintVerboseVote(vote)
 		}(ag)
 	}
 
@@ -50,54 +77,163 @@ func (c *Council) Vote(ctx context.Context) error {
 	// Log errors but don't fail - we proceed with whatever votes we got
 	for err := range errChan {
 		if c.config.Verbose {
-			fmt.Printf("Warning: %v\n", err)
+			fmt.Fprintf(c.progress, "Warning: %v\n", err)
 		}
 	}
 
 	// Sort votes by voter ID for consistent ordering
-	sort.Slice(c.session.Votes, func(i, j int) bool {
-		return c.session.Votes[i].VoterID < c.session.Votes[j].VoterID
+	sort.Slice(votes, func(i, j int) bool {
+		return votes[i].VoterID < votes[j].VoterID
 	})
 
-	return nil
+	return votes
 }
 
-// Tally calculates scores and determines the winner
-func (c *Council) Tally() {
-	n := len(c.agents)
+// borda scores votes over the candidates with Borda count: with N candidates,
+// 1st place = (N-1) points, 2nd = (N-2), etc.
+func borda(votes []types.Vote, candidates []int) map[int]int {
+	n := len(candidates)
+	scores := make(map[int]int)
 
-	// Initialize scores for all agents
-	for _, ag := range c.agents {
-		c.session.Scores[ag.ID] = 0
+	// Initialize scores for all candidates
+	for _, id := range candidates {
+		scores[id] = 0
 	}
 
-	// Calculate points: 1st place = (N-1) points, 2nd = (N-2), etc.
-	for _, vote := range c.session.Votes {
+	for _, vote := range votes {
 		for i, agentID := range vote.Rankings {
 			points := n - 1 - i
 			if points > 0 {
-				c.session.Scores[agentID] += points
+				scores[agentID] += points
 			}
 		}
 	}
 
-	// Find winner(s)
-	maxScore := 0
-	for _, score := range c.session.Scores {
+	return scores
+}
+
+// weightedBorda scores votes like borda, multiplying each vote's points by its effective weight
+func (c *Council) weightedBorda(votes []types.Vote, candidates []int) map[int]float64 {
+	n := len(candidates)
+	scores := make(map[int]float64)
+
+	for _, id := range candidates {
+		scores[id] = 0
+	}
+
+	for _, vote := range votes {
+		for i, agentID := range vote.Rankings {
+			points := n - 1 - i
+			if points > 0 {
+				scores[agentID] += float64(points) * c.effectiveWeight(vote)
+			}
+		}
+	}
+
+	return scores
+}
+
+// voteWeight returns the weight of a vote, where an unset weight counts as 1
+func voteWeight(vote types.Vote) float64 {
+	if vote.Weight == 0 {
+		return 1
+	}
+	return vote.Weight
+}
+
+// effectiveWeight returns the weight a vote counts with: its voter's weight,
+// times the voter's confidence with --weigh-confidence. Vote.Weight itself
+// stays the voter's weight, so the confidence isn't recorded twice.
+func (c *Council) effectiveWeight(vote types.Vote) float64 {
+	weight := voteWeight(vote)
+	if c.config.WeighConfidence && vote.Confidence > 0 {
+		weight *= vote.Confidence
+	}
+	return weight
+}
+
+// weigh sets each vote's weight from the session's vote weights, for voters that have one
+func (c *Council) weigh(votes []types.Vote) {
+	for i := range votes {
+		if w, ok := c.session.Weights[votes[i].VoterID]; ok {
+			votes[i].Weight = w
+		}
+	}
+}
+
+// weighted reports whether any vote counts with a weight other than 1
+func (c *Council) weighted(votes []types.Vote) bool {
+	for _, vote := range votes {
+		if c.effectiveWeight(vote) != 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// leaders returns the agents with the highest score, in ID order
+func leaders[S int | float64](scores map[int]S) []int {
+	var maxScore S
+	for _, score := range scores {
 		if score > maxScore {
 			maxScore = score
 		}
 	}
 
-	var winners []int
-	for agentID, score := range c.session.Scores {
+	var ids []int
+	for agentID, score := range scores {
 		if score == maxScore {
-			winners = append(winners, agentID)
+			ids = append(ids, agentID)
 		}
 	}
 
-	sort.Ints(winners)
+	sort.Ints(ids)
+	return ids
+}
+
+// Tally calculates scores and determines the winner
+func (c *Council) Tally() {
+	if c.config.Voting == types.VotingPairwise {
+		c.tallyPairwise()
+		return
+	}
+
+	c.weigh(c.session.Votes)
+	c.session.Abstentions, c.session.FailedVotes = turnout(c.session.Votes)
+
+	// Weighted votes decide the winner on weighted points; the raw points are kept alongside
+	c.session.Scores = borda(c.session.Votes, c.candidates())
+	if c.weighted(c.session.Votes) {
+		c.session.WeightedScores = c.weightedBorda(c.session.Votes, c.candidates())
+		c.setWinners(leaders(c.session.WeightedScores))
+	} else {
+		c.setWinners(leaders(c.session.Scores))
+	}
+	if c.config.Cluster != nil && c.config.Cluster.Tally {
+		c.tallyClusters()
+	}
+	if len(c.config.Rubric) > 0 {
+		c.tallyRubric()
+	}
+}
+
+// turnout returns the IDs of the voters who abstained and of those whose vote
+// failed. Neither ranks anything, so both add no points, but they're kept apart
+// so a failure isn't mistaken for a voter's choice not to judge.
+func turnout(votes []types.Vote) (abstained, failed []int) {
+	for _, vote := range votes {
+		switch vote.Status {
+		case types.VoteAbstained:
+			abstained = append(abstained, vote.VoterID)
+		case types.VoteFailed:
+			failed = append(failed, vote.VoterID)
+		}
+	}
+	return abstained, failed
+}
 
+// setWinners records the winner, or a tie between several
+func (c *Council) setWinners(winners []int) {
 	if len(winners) == 1 {
 		c.session.WinnerID = &winners[0]
 		c.session.IsTie = false
@@ -0,0 +1,80 @@
+package council
+
+import (
+	"math"
+	"testing"
+
+	"github.com/humzahkiani/council/internal/agent"
+	"github.com/humzahkiani/council/internal/types"
+)
+
+// testCouncil returns a council of n agents with an empty session, for tallying without an API
+func testCouncil(n int, config *types.Config) *Council {
+	c := &Council{
+		config:  config,
+		session: &types.Session{Scores: make(map[int]int)},
+	}
+	for id := 1; id <= n; id++ {
+		c.agents = append(c.agents, agent.New(id, n, nil))
+	}
+	return c
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestEffectiveWeight(t *testing.T) {
+	tests := []struct {
+		name       string
+		weigh      bool
+		weight     float64
+		confidence float64
+		want       float64
+	}{
+		{"unset weight", false, 0, 0, 1},
+		{"voter weight", false, 2, 0.5, 2},
+		{"confidence ignored when off", false, 0, 0.5, 1},
+		{"confidence multiplies", true, 0, 0.5, 0.5},
+		{"confidence times voter weight", true, 2, 0.5, 1},
+		{"no confidence given", true, 2, 0, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testCouncil(3, &types.Config{WeighConfidence: tt.weigh})
+			vote := types.Vote{VoterID: 1, Weight: tt.weight, Confidence: tt.confidence}
+			if got := c.effectiveWeight(vote); !near(got, tt.want) {
+				t.Errorf("effectiveWeight = %g, want %g", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTallyKeepsVoterWeight(t *testing.T) {
+	c := testCouncil(3, &types.Config{WeighConfidence: true})
+	c.session.Weights = map[int]float64{2: 2}
+	c.session.Votes = []types.Vote{
+		{VoterID: 1, Rankings: []int{2, 3}, Confidence: 0.5},
+		{VoterID: 2, Rankings: []int{3, 1}, Confidence: 1},
+		{VoterID: 3, Rankings: []int{2, 1}, Confidence: 0.9},
+	}
+
+	// Tallying again, as a resumed run does, must give the same result
+	for range 2 {
+		c.Tally()
+
+		if got := []float64{c.session.Votes[0].Weight, c.session.Votes[1].Weight, c.session.Votes[2].Weight}; got[0] != 0 || got[1] != 2 || got[2] != 0 {
+			t.Errorf("vote weights = %v, want the voter weights [0 2 0]", got)
+		}
+		want := map[int]float64{1: 2.9, 2: 2.8, 3: 4.5}
+		for id, w := range want {
+			if !near(c.session.WeightedScores[id], w) {
+				t.Errorf("weighted score of %d = %g, want %g", id, c.session.WeightedScores[id], w)
+			}
+		}
+		if c.session.WinnerID == nil || *c.session.WinnerID != 3 {
+			t.Errorf("winner = %v, want 3", c.session.WinnerID)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package council
+
+import (
+	"fmt"
+
+	"github.com/humzahkiani/council/internal/storage"
+	"github.com/humzahkiani/council/internal/types"
+)
+
+// historyLimit caps how many recent sessions --weights accuracy learns from
+const historyLimit = 200
+
+// resolveWeights sets the session's vote weights, either from the config or,
+// with --weights accuracy, derived from the graded sessions of past bench runs
+func (c *Council) resolveWeights() error {
+	if !c.config.AccuracyWeights {
+		c.session.Weights = c.config.Weights
+		return nil
+	}
+
+	var voters []int
+	keys := make(map[string]bool)
+	for _, ag := range c.voters() {
+		voters = append(voters, ag.ID)
+		keys[voterKey(c.session, ag.ID)] = true
+	}
+	// Voters with the same persona and model share a record, so weights can only tell apart voters that differ
+	if len(voters) > 1 && len(keys) == 1 {
+		return fmt.Errorf("--weights accuracy needs voters that differ by persona or model, but every voter is %s; assign --personas or add judges on another --judge-model", voterKey(c.session, voters[0]))
+	}
+
+	store, err := storage.New()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	history, err := store.List()
+	if err != nil {
+		return fmt.Errorf("failed to load past sessions: %w", err)
+	}
+	if len(history) > historyLimit {
+		history = history[:historyLimit]
+	}
+
+	c.session.Weights = accuracyWeights(history, c.session, voters)
+	return nil
+}
+
+// accuracyWeights weights each voter by how often its first choice was graded
+// correct in past sessions that council bench checked against reference
+// answers. Voters are matched across sessions by persona and model, not by
+// slot. Accuracy is smoothed towards 1/2, which is also what voters without a
+// record get, and the weights are scaled to average 1. Returns nil when no
+// graded votes match.
+func accuracyWeights(history []*types.Session, current *types.Session, voters []int) map[int]float64 {
+	type record struct{ correct, total int }
+	records := make(map[string]*record)
+
+	for _, s := range history {
+		if len(s.Grades) == 0 {
+			continue
+		}
+		for _, vote := range s.Votes {
+			if vote.VoterID == types.HumanID || len(vote.Rankings) == 0 {
+				continue
+			}
+			correct, graded := s.Grades[vote.Rankings[0]]
+			if !graded {
+				continue
+			}
+			key := voterKey(s, vote.VoterID)
+			if records[key] == nil {
+				records[key] = &record{}
+			}
+			records[key].total++
+			if correct {
+				records[key].correct++
+			}
+		}
+	}
+
+	weights := make(map[int]float64)
+	matched := false
+	sum := 0.0
+	for _, id := range voters {
+		r := records[voterKey(current, id)]
+		if r == nil {
+			r = &record{}
+		} else {
+			matched = true
+		}
+		weights[id] = float64(r.correct+1) / float64(r.total+2)
+		sum += weights[id]
+	}
+	if !matched {
+		return nil
+	}
+
+	mean := sum / float64(len(voters))
+	for id := range weights {
+		weights[id] /= mean
+	}
+	return weights
+}
+
+// voterKey identifies a voter across sessions by its persona, or its role
+// without one, and the model it ran on, e.g. "Skeptic on claude-sonnet-4-20250514"
+func voterKey(s *types.Session, id int) string {
+	role, model := "agent", s.Model
+	if s.Judges > 0 && id > s.AgentCount {
+		role = "judge"
+		if s.Config != nil && s.Config.JudgeModel != "" {
+			model = s.Config.JudgeModel
+		}
+	}
+	if name := s.Personas[id]; name != "" {
+		role = name
+	}
+	return fmt.Sprintf("%s on %s", role, model)
+}
@@ -0,0 +1,128 @@
+package council
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+// gradedSession is a past bench session whose voters ranked firsts[voter] top,
+// with the given solutions graded correct and the rest incorrect
+func gradedSession(personas map[int]string, firsts map[int]int, correct ...int) *types.Session {
+	s := &types.Session{AgentCount: 3, Model: "m", Personas: personas, Grades: map[int]bool{1: false, 2: false, 3: false}}
+	for _, id := range correct {
+		s.Grades[id] = true
+	}
+	for voter := 1; voter <= 3; voter++ {
+		if first, ok := firsts[voter]; ok {
+			s.Votes = append(s.Votes, types.Vote{VoterID: voter, Rankings: []int{first}})
+		}
+	}
+	return s
+}
+
+func TestVoterKey(t *testing.T) {
+	session := &types.Session{
+		AgentCount: 3,
+		Judges:     1,
+		Model:      "small",
+		Personas:   map[int]string{2: "Skeptic"},
+		Config:     &types.Config{JudgeModel: "large"},
+	}
+
+	tests := []struct {
+		id   int
+		want string
+	}{
+		{1, "agent on small"},
+		{3, "agent on small"},
+		{2, "Skeptic on small"},
+		{4, "judge on large"},
+	}
+	for _, tt := range tests {
+		if got := voterKey(session, tt.id); got != tt.want {
+			t.Errorf("voterKey(%d) = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestAccuracyWeights(t *testing.T) {
+	skeptic := map[int]string{1: "Skeptic"}
+
+	tests := []struct {
+		name     string
+		history  []*types.Session
+		personas map[int]string
+		want     map[int]float64 // nil when no record matches
+	}{
+		{
+			name: "no history",
+			want: nil,
+		},
+		{
+			name: "persona record follows it to another slot",
+			// The skeptic, as agent 1, always picked a wrong solution; the plain agents always a right one
+			history: []*types.Session{
+				gradedSession(skeptic, map[int]int{1: 2, 2: 3, 3: 1}, 3, 1),
+				gradedSession(skeptic, map[int]int{1: 3, 3: 2}, 2),
+			},
+			personas: map[int]string{3: "Skeptic"},
+			// Skeptic: (0+1)/(2+2) = 0.25; plain agents: (3+1)/(3+2) = 0.8; mean 1.85/3
+			want: map[int]float64{1: 0.8 / (1.85 / 3), 2: 0.8 / (1.85 / 3), 3: 0.25 / (1.85 / 3)},
+		},
+		{
+			name: "ungraded sessions are skipped",
+			history: []*types.Session{
+				{AgentCount: 3, Model: "m", Votes: []types.Vote{{VoterID: 1, Rankings: []int{2}}}},
+			},
+			want: nil,
+		},
+		{
+			name: "first choices without a grade are skipped",
+			history: []*types.Session{
+				{AgentCount: 3, Model: "m", Grades: map[int]bool{1: true}, Votes: []types.Vote{{VoterID: 1, Rankings: []int{2}}}},
+			},
+			want: nil,
+		},
+		{
+			name: "abstentions are skipped",
+			history: []*types.Session{
+				func() *types.Session {
+					s := gradedSession(nil, nil, 1)
+					s.Votes = []types.Vote{{VoterID: 2, Rankings: []int{}, Status: types.VoteAbstained}}
+					return s
+				}(),
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := &types.Session{AgentCount: 3, Model: "m", Personas: tt.personas}
+			got := accuracyWeights(tt.history, current, []int{1, 2, 3})
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("weights = %v, want nil", got)
+				}
+				return
+			}
+			for id, want := range tt.want {
+				if !near(got[id], want) {
+					t.Errorf("weight of %d = %g, want %g (all: %v)", id, got[id], want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveWeightsNeedsDistinctVoters(t *testing.T) {
+	c := testCouncil(3, &types.Config{AccuracyWeights: true})
+	c.session.Model = "m"
+
+	err := c.resolveWeights()
+	if err == nil || !strings.Contains(err.Error(), "every voter is agent on m") {
+		t.Errorf("error = %v, want one naming the shared record", err)
+	}
+}
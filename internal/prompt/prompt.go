@@ -0,0 +1,279 @@
+// Package prompt renders the system prompts for each council phase from
+// text/template files. Built-in defaults are embedded; any of them can be
+// overridden by placing a file of the same name in a prompts directory.
+//
+// Templates are executed with a Data value, so the following variables are
+// available in every phase:
+//
+//	{{.AgentID}}             the agent's numeric ID
+//	{{.Name}}                the agent's display name, e.g. "Agent 2 (Skeptic)"
+//	{{.Total}}               the number of agents in the council
+//	{{.Judge}}               true for a separate judge, which has no solution of its own
+//	{{.Task}}                the task text
+//	{{.Solutions}}           []types.Solution (empty during generation; the top-ranked ones during synthesis; the pair being judged, in the order shown)
+//	{{.Critiques}}           []types.Critique (empty before voting)
+//	{{.Round}}               the discussion round (0 during generation)
+//	{{.Persona}}             *types.Persona, nil if none assigned
+//	{{.PersonaInstructions}} the persona's instructions for this phase
+//	{{.Tested}}              true when solutions are shown with their test results
+//	{{.Moderation}}          *types.ModeratorNote for the previous round, nil without a moderator
+//	{{.FinalRound}}          true when moderating the last configured round
+//	{{.Rubric}}              []types.Criterion voters score solutions on, empty without a rubric
+package prompt
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/humzahkiani/council/internal/types"
+)
+
+// Name identifies the template for one phase
+type Name string
+
+const (
+	Generate   Name = "generate"
+	Discuss    Name = "discuss"
+	Vote       Name = "vote"
+	Synthesize Name = "synthesize"
+	Moderate   Name = "moderate"
+	Judge      Name = "judge"
+)
+
+// Names lists every template, in the order they are hashed
+var Names = []Name{Generate, Discuss, Vote, Synthesize, Moderate, Judge}
+
+// Shared holds {{define}} blocks that every phase's template can use, such as
+// "testResults". It is not a phase of its own, but can be overridden the same way.
+const Shared Name = "shared"
+
+//go:embed defaults/*.tmpl
+var defaults embed.FS
+
+// Data holds the variables available to every template
+type Data struct {
+	AgentID             int
+	Name                string
+	Total               int
+	Judge               bool
+	Task                string
+	Solutions           []types.Solution
+	Critiques           []types.Critique
+	Round               int
+	Persona             *types.Persona
+	PersonaInstructions string
+	Tested              bool
+	Moderation          *types.ModeratorNote
+	FinalRound          bool
+	Rubric              []types.Criterion
+}
+
+// Set is a validated collection of phase templates
+type Set struct {
+	templates map[Name]*template.Template
+	hash      string
+}
+
+// Default returns the built-in templates
+func Default() *Set {
+	set, err := load("")
+	if err != nil {
+		// The embedded templates are fixed at build time, so this is a programming error
+		panic(fmt.Sprintf("invalid built-in prompt templates: %v", err))
+	}
+	return set
+}
+
+// Load reads templates from dir, falling back to the built-in template for
+// any phase without a file. An empty dir uses ~/.council/prompts/ if it exists.
+func Load(dir string) (*Set, error) {
+	if dir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get home directory: %w", err)
+		}
+		dir = filepath.Join(homeDir, ".council", "prompts")
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			return load("")
+		}
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompts directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("prompts path is not a directory: %s", dir)
+	}
+
+	return load(dir)
+}
+
+// load parses and validates every template, reading overrides from dir when set
+func load(dir string) (*Set, error) {
+	if dir != "" {
+		if err := checkUnknown(dir); err != nil {
+			return nil, err
+		}
+	}
+
+	set := &Set{templates: make(map[Name]*template.Template)}
+	h := sha256.New()
+
+	shared, sharedOrigin, err := readSource(dir, Shared)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range Names {
+		source, origin, err := readSource(dir, name)
+		if err != nil {
+			return nil, err
+		}
+
+		// The shared blocks are parsed first, so a phase's own {{define}} takes precedence
+		tmpl := template.New(string(name)).Option("missingkey=error")
+		if _, err := tmpl.New(string(Shared)).Parse(shared); err != nil {
+			return nil, fmt.Errorf("invalid %s template (%s): %w", Shared, sharedOrigin, err)
+		}
+		if _, err := tmpl.Parse(source); err != nil {
+			return nil, fmt.Errorf("invalid %s template (%s): %w", name, origin, err)
+		}
+		if err := validate(tmpl); err != nil {
+			return nil, fmt.Errorf("invalid %s template (%s): %w", name, origin, err)
+		}
+
+		set.templates[name] = tmpl
+		fmt.Fprintf(h, "%s\x00%s\x00", name, source)
+	}
+	fmt.Fprintf(h, "%s\x00%s\x00", Shared, shared)
+
+	set.hash = hex.EncodeToString(h.Sum(nil))
+	return set, nil
+}
+
+// readSource returns a template's source and where it came from
+func readSource(dir string, name Name) (string, string, error) {
+	filename := string(name) + ".tmpl"
+
+	if dir != "" {
+		path := filepath.Join(dir, filename)
+		data, err := os.ReadFile(path)
+		if err == nil {
+			return string(data), path, nil
+		}
+		if !os.IsNotExist(err) {
+			return "", "", fmt.Errorf("failed to read %s template: %w", name, err)
+		}
+	}
+
+	data, err := defaults.ReadFile("defaults/" + filename)
+	if err != nil {
+		return "", "", fmt.Errorf("missing built-in %s template: %w", name, err)
+	}
+	return string(data), "built-in", nil
+}
+
+// checkUnknown rejects template files that don't correspond to a phase, which are usually typos
+func checkUnknown(dir string) error {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read prompts directory: %w", err)
+	}
+
+	known := map[string]bool{string(Shared) + ".tmpl": true}
+	for _, name := range Names {
+		known[string(name)+".tmpl"] = true
+	}
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".tmpl") {
+			continue
+		}
+		if !known[file.Name()] {
+			return fmt.Errorf("unknown prompt template %s in %s", file.Name(), dir)
+		}
+	}
+
+	return nil
+}
+
+// validate executes a template against sample data so field typos surface at startup
+func validate(tmpl *template.Template) error {
+	persona := &types.Persona{Name: "Reviewer", Instructions: "Review carefully."}
+	judge := sampleData(nil)
+	judge.AgentID, judge.Name, judge.Judge = 4, "Judge 1", true
+	samples := []Data{
+		sampleData(nil),
+		sampleData(persona),
+		judge,
+	}
+
+	for _, data := range samples {
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sampleData returns representative template variables for validation
+func sampleData(persona *types.Persona) Data {
+	now := time.Now()
+	return Data{
+		AgentID: 1,
+		Name:    "Agent 1",
+		Total:   3,
+		Task:    "Sample task",
+		Solutions: []types.Solution{
+			{AgentID: 1, Content: "Sample solution", CreatedAt: now},
+			{AgentID: 2, Content: "Sample solution", CreatedAt: now},
+		},
+		Critiques: []types.Critique{
+			{AgentID: 2, Round: 1, Content: "Sample critique", CreatedAt: now},
+		},
+		Round:               1,
+		Persona:             persona,
+		PersonaInstructions: "Review carefully.",
+		Tested:              true,
+		Rubric: []types.Criterion{
+			{Name: "correctness", Weight: 0.7},
+			{Name: "clarity", Weight: 0.3},
+		},
+		Moderation: &types.ModeratorNote{
+			Round:         1,
+			Summary:       "Sample summary",
+			Disagreements: []string{"Sample disagreement"},
+			Questions:     []types.ModeratorQuestion{{AgentID: 1, Question: "Sample question"}},
+			Continue:      true,
+			CreatedAt:     now,
+		},
+	}
+}
+
+// Render executes the named template with the given data
+func (s *Set) Render(name Name, data Data) (string, error) {
+	tmpl, ok := s.templates[name]
+	if !ok {
+		return "", fmt.Errorf("no %s template", name)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render %s prompt: %w", name, err)
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
+
+// Hash returns a SHA-256 over every resolved template source, identifying the exact prompts used
+func (s *Set) Hash() string {
+	return s.hash
+}
@@ -0,0 +1,92 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testResultsText = "Each solution's code was run against the test suite"
+
+func TestTestResultsShared(t *testing.T) {
+	set := Default()
+
+	tests := []struct {
+		name   Name
+		tested bool
+		want   int
+	}{
+		{Discuss, true, 1},
+		{Vote, true, 1},
+		{Judge, true, 1},
+		{Discuss, false, 0},
+		{Vote, false, 0},
+		{Judge, false, 0},
+		{Generate, true, 0},
+	}
+
+	for _, tt := range tests {
+		data := sampleData(nil)
+		data.Tested = tt.tested
+		out, err := set.Render(tt.name, data)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := strings.Count(out, testResultsText); got != tt.want {
+			t.Errorf("%s tested=%v: paragraph appears %d times, want %d", tt.name, tt.tested, got, tt.want)
+		}
+	}
+}
+
+func TestSharedOverride(t *testing.T) {
+	dir := t.TempDir()
+	shared := `{{define "testResults"}}{{if .Tested}} Tests ran.{{end}}{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, "shared.tmpl"), []byte(shared), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	set, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if set.Hash() == Default().Hash() {
+		t.Error("overriding shared.tmpl did not change the hash")
+	}
+
+	for _, name := range []Name{Discuss, Vote, Judge} {
+		out, err := set.Render(name, sampleData(nil))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !strings.Contains(out, "Tests ran.") || strings.Contains(out, testResultsText) {
+			t.Errorf("%s did not use the overridden block:\n%s", name, out)
+		}
+	}
+}
+
+func TestLoadRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{"unknown file", "critique.tmpl", "x", "unknown prompt template"},
+		{"bad shared block", "shared.tmpl", `{{define "testResults"}}{{.Nope}}{{end}}`, "invalid discuss template"},
+		{"unparseable shared", "shared.tmpl", `{{define "testResults"}}`, "invalid shared template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, tt.file), []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := Load(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
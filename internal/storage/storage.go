@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/humzahkiani/council/internal/types"
 )
@@ -72,6 +74,31 @@ func (s *Storage) Load(path string) (*types.Session, error) {
 	return &session, nil
 }
 
+// List loads every session in the default sessions directory, newest first, skipping files that fail to load
+func (s *Storage) List() ([]*types.Session, error) {
+	files, err := os.ReadDir(s.baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sessions directory: %w", err)
+	}
+
+	var sessions []*types.Session
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		session, err := s.Load(filepath.Join(s.baseDir, file.Name()))
+		if err != nil {
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+	})
+	return sessions, nil
+}
+
 // generateFilename creates a filename in the format: YYYY-MM-DD_HHMMSS_{uuid[:6]}.json
 func (s *Storage) generateFilename(session *types.Session) string {
 	timestamp := session.CreatedAt.Format("2006-01-02_150405")
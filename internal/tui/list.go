@@ -26,7 +26,7 @@ func (i SessionItem) Title() string {
 	}
 	winner := "?"
 	if i.Session.WinnerID != nil {
-		winner = fmt.Sprintf("Agent %d", *i.Session.WinnerID)
+		winner = i.Session.AgentLabel(*i.Session.WinnerID)
 	}
 	return fmt.Sprintf("%s won - %s", winner, truncate(i.Session.Task, 40))
 }
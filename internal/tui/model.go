@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/viewport"
@@ -18,6 +19,8 @@ const (
 	TabDiscussion
 	TabVotes
 	TabResults
+	TabSynthesis
+	TabBracket
 )
 
 func (t Tab) String() string {
@@ -30,6 +33,10 @@ func (t Tab) String() string {
 		return "Votes"
 	case TabResults:
 		return "Results"
+	case TabSynthesis:
+		return "Synthesis"
+	case TabBracket:
+		return "Bracket"
 	default:
 		return ""
 	}
@@ -38,23 +45,46 @@ func (t Tab) String() string {
 // Model is the main TUI model
 type Model struct {
 	session       *types.Session
+	tabs          []Tab // Tabs shown for this session, in order
 	activeTab     Tab
 	viewport      viewport.Model
 	width         int
 	height        int
 	ready         bool
-	selectedAgent int // For solutions tab, which agent's solution to highlight
+	selectedAgent int  // For solutions tab, which agent's solution to highlight
+	showThinking  bool // Reveal stored extended thinking alongside each entry
 }
 
 // NewModel creates a new TUI model for viewing a session
 func NewModel(session *types.Session) Model {
+	tabs := []Tab{TabSolutions, TabDiscussion, TabVotes, TabResults}
+	if session.Synthesis != nil {
+		tabs = append(tabs, TabSynthesis)
+	}
+	if session.Bracket != nil {
+		tabs = append(tabs, TabBracket)
+	}
+
 	return Model{
 		session:       session,
+		tabs:          tabs,
 		activeTab:     TabSolutions,
 		selectedAgent: 1,
 	}
 }
 
+// switchTab moves delta tabs from the active one, wrapping around
+func (m *Model) switchTab(delta int) {
+	current := 0
+	for i, t := range m.tabs {
+		if t == m.activeTab {
+			current = i
+		}
+	}
+	n := len(m.tabs)
+	m.activeTab = m.tabs[((current+delta)%n+n)%n]
+}
+
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
 	return nil
@@ -70,22 +100,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "q", "ctrl+c":
 			return m, tea.Quit
 		case "tab", "l", "right":
-			m.activeTab = Tab((int(m.activeTab) + 1) % 4)
+			m.switchTab(1)
 			m.updateViewport()
 		case "shift+tab", "h", "left":
-			m.activeTab = Tab((int(m.activeTab) + 3) % 4) // +3 is same as -1 mod 4
-			m.updateViewport()
-		case "1":
-			m.activeTab = TabSolutions
+			m.switchTab(-1)
 			m.updateViewport()
-		case "2":
-			m.activeTab = TabDiscussion
-			m.updateViewport()
-		case "3":
-			m.activeTab = TabVotes
-			m.updateViewport()
-		case "4":
-			m.activeTab = TabResults
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			if i := int(msg.String()[0] - '1'); i < len(m.tabs) {
+				m.activeTab = m.tabs[i]
+				m.updateViewport()
+			}
+		case "t":
+			m.showThinking = !m.showThinking
 			m.updateViewport()
 		case "j", "down":
 			if m.activeTab == TabSolutions {
@@ -142,6 +168,10 @@ func (m *Model) updateViewport() {
 		content = m.renderVotes()
 	case TabResults:
 		content = m.renderResults()
+	case TabSynthesis:
+		content = m.renderSynthesis()
+	case TabBracket:
+		content = m.renderBracket()
 	}
 	m.viewport.SetContent(content)
 }
@@ -179,9 +209,8 @@ func (m Model) View() string {
 // renderTabs renders the tab bar
 func (m Model) renderTabs() string {
 	var tabs []string
-	allTabs := []Tab{TabSolutions, TabDiscussion, TabVotes, TabResults}
 
-	for _, t := range allTabs {
+	for _, t := range m.tabs {
 		style := inactiveTabStyle
 		if t == m.activeTab {
 			style = activeTabStyle
@@ -201,7 +230,7 @@ func (m Model) renderSolutions() string {
 		isSelected := sol.AgentID == m.selectedAgent
 
 		// Agent header
-		label := fmt.Sprintf("Agent %d", sol.AgentID)
+		label := m.session.AgentLabel(sol.AgentID)
 		if isWinner {
 			label += " ★ WINNER"
 		}
@@ -219,16 +248,47 @@ func (m Model) renderSolutions() string {
 		// Score
 		score := m.session.Scores[sol.AgentID]
 		scoreText := fmt.Sprintf("%d points", score)
+		if answer, ok := m.session.Answers[sol.AgentID]; ok {
+			scoreText = fmt.Sprintf("answer %s, given by %d", answerText(answer), score)
+		}
 		if isWinner {
 			sb.WriteString(winnerScoreStyle.Render(scoreText))
 		} else {
 			sb.WriteString(scoreStyle.Render(scoreText))
 		}
+		if sol.Truncated {
+			sb.WriteString(" ")
+			sb.WriteString(warningStyle.Render("truncated at max_tokens"))
+		}
+		if v := sol.Verification; v != nil {
+			sb.WriteString(" ")
+			switch {
+			case v.Passed:
+				sb.WriteString(passStyle.Render("tests passed"))
+			case v.Error != "":
+				sb.WriteString(failStyle.Render("tests error: " + v.Error))
+			default:
+				sb.WriteString(failStyle.Render(fmt.Sprintf("tests failed (exit %d)", v.ExitCode)))
+			}
+		}
+		if others := m.clusterMates(sol.AgentID); len(others) > 0 {
+			sb.WriteString(" ")
+			sb.WriteString(mutedTextStyle.Render("near-duplicate of " + m.agentLabels(others)))
+		}
 		sb.WriteString("\n\n")
 
+		m.writeThinking(&sb, sol.Thinking)
+
 		// Solution content
 		sb.WriteString(contentStyle.Render(sol.Content))
 		sb.WriteString("\n\n")
+
+		if v := sol.Verification; v != nil && strings.TrimSpace(v.Output) != "" {
+			sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("Test output (%s, %dms)", strings.Join(v.Files, ", "), v.DurationMS)))
+			sb.WriteString("\n")
+			sb.WriteString(mutedTextStyle.Render(strings.TrimSpace(v.Output)))
+			sb.WriteString("\n\n")
+		}
 		sb.WriteString(divider(m.width - 8))
 		sb.WriteString("\n\n")
 	}
@@ -247,24 +307,124 @@ func (m Model) renderDiscussion() string {
 	currentRound := 0
 	for _, crit := range m.session.Critiques {
 		if crit.Round != currentRound {
+			m.writeRoundEnd(&sb, currentRound)
 			currentRound = crit.Round
 			sb.WriteString(headerStyle.Render(fmt.Sprintf("Round %d", currentRound)))
 			sb.WriteString("\n\n")
 		}
 
-		sb.WriteString(subHeaderStyle.Render(fmt.Sprintf("Agent %d's Critique", crit.AgentID)))
+		sb.WriteString(subHeaderStyle.Render(fmt.Sprintf("%s's Critique", m.session.AgentLabel(crit.AgentID))))
 		sb.WriteString("\n\n")
+		m.writeThinking(&sb, crit.Thinking)
 		sb.WriteString(contentStyle.Render(crit.Content))
 		sb.WriteString("\n\n")
 		sb.WriteString(divider(m.width - 8))
 		sb.WriteString("\n\n")
 	}
 
+	m.writeRoundEnd(&sb, currentRound)
+
+	if end := m.session.DiscussionEnd; end != nil {
+		sb.WriteString(warningStyle.Render(fmt.Sprintf("Discussion ended after round %d of %d (%s): %s", end.Round, m.session.Rounds, end.Reason, end.Detail)))
+		sb.WriteString("\n")
+	}
+
 	return sb.String()
 }
 
+// writeRoundEnd writes what followed a discussion round: the moderator's note and straw poll, if any
+func (m Model) writeRoundEnd(sb *strings.Builder, round int) {
+	m.writeModeration(sb, round)
+	m.writeStrawPoll(sb, round)
+}
+
+// writeStrawPoll writes the straw poll taken after a round, if there was one
+func (m Model) writeStrawPoll(sb *strings.Builder, round int) {
+	for _, poll := range m.session.StrawPolls {
+		if poll.Round != round {
+			continue
+		}
+
+		sb.WriteString(subHeaderStyle.Render(fmt.Sprintf("Straw Poll after Round %d", round)))
+		sb.WriteString("\n\n")
+
+		for _, i := range sortedIDs(poll.Scores) {
+			line := fmt.Sprintf("%s: %d points", m.session.AgentLabel(i), poll.Scores[i])
+			if weighted, ok := poll.WeightedScores[i]; ok {
+				line = fmt.Sprintf("%s: %.1f weighted points (%d unweighted)", m.session.AgentLabel(i), weighted, poll.Scores[i])
+			}
+			sb.WriteString(contentStyle.Render(line))
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+
+		switch {
+		case poll.LeaderID == nil:
+			sb.WriteString(mutedTextStyle.Render("No clear leader"))
+		case poll.Consensus:
+			sb.WriteString(winnerStyle.Render(fmt.Sprintf("Consensus: %s ranked first by %.0f%% of the other agents%s", m.session.AgentLabel(*poll.LeaderID), poll.Agreement*100, byWeight(poll))))
+		default:
+			sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("%s leads, ranked first by %.0f%% of the other agents%s", m.session.AgentLabel(*poll.LeaderID), poll.Agreement*100, byWeight(poll))))
+		}
+		sb.WriteString("\n\n")
+		sb.WriteString(divider(m.width - 8))
+		sb.WriteString("\n\n")
+	}
+}
+
+// writeModeration writes the moderator's note for a round, if there is one
+func (m Model) writeModeration(sb *strings.Builder, round int) {
+	for _, note := range m.session.Moderation {
+		if note.Round != round {
+			continue
+		}
+
+		sb.WriteString(subHeaderStyle.Render(fmt.Sprintf("Moderator's Summary of Round %d", round)))
+		sb.WriteString("\n\n")
+		m.writeThinking(sb, note.Thinking)
+		sb.WriteString(contentStyle.Render(note.Summary))
+		sb.WriteString("\n")
+
+		if len(note.Disagreements) > 0 {
+			sb.WriteString("\n")
+			sb.WriteString(mutedTextStyle.Render("Disagreements:"))
+			sb.WriteString("\n")
+			for _, d := range note.Disagreements {
+				sb.WriteString(contentStyle.Render("• " + d))
+				sb.WriteString("\n")
+			}
+		}
+
+		if len(note.Questions) > 0 {
+			sb.WriteString("\n")
+			sb.WriteString(mutedTextStyle.Render("Questions:"))
+			sb.WriteString("\n")
+			for _, q := range note.Questions {
+				sb.WriteString(contentStyle.Render(fmt.Sprintf("• %s: %s", m.session.AgentLabel(q.AgentID), q.Question)))
+				sb.WriteString("\n")
+			}
+		}
+
+		decision := "Another round needed"
+		if !note.Continue {
+			decision = "No further rounds needed"
+		}
+		if note.Reason != "" {
+			decision += ": " + note.Reason
+		}
+		sb.WriteString("\n")
+		sb.WriteString(mutedTextStyle.Render(decision))
+		sb.WriteString("\n\n")
+		sb.WriteString(divider(m.width - 8))
+		sb.WriteString("\n\n")
+	}
+}
+
 // renderVotes renders the votes view
 func (m Model) renderVotes() string {
+	if len(m.session.Judgments) > 0 {
+		return m.renderJudgments()
+	}
 	if len(m.session.Votes) == 0 {
 		return mutedTextStyle.Render("No votes recorded.")
 	}
@@ -275,17 +435,31 @@ func (m Model) renderVotes() string {
 	sb.WriteString("\n\n")
 
 	for _, vote := range m.session.Votes {
-		sb.WriteString(subHeaderStyle.Render(fmt.Sprintf("Agent %d's Vote", vote.VoterID)))
+		heading := fmt.Sprintf("%s's Vote", m.session.AgentLabel(vote.VoterID))
+		if vote.Weight != 0 && vote.Weight != 1 {
+			heading += fmt.Sprintf(" (weight %g)", vote.Weight)
+		}
+		if vote.Confidence > 0 {
+			heading += fmt.Sprintf(" (confidence %.2f)", vote.Confidence)
+		}
+		sb.WriteString(subHeaderStyle.Render(heading))
 		sb.WriteString("\n")
 
-		// Rankings
-		sb.WriteString(mutedTextStyle.Render("Rankings: "))
+		// Abstentions and failures rank nothing
+		switch vote.Status {
+		case types.VoteAbstained:
+			sb.WriteString(mutedTextStyle.Render("Abstained"))
+		case types.VoteFailed:
+			sb.WriteString(warningStyle.Render("Failed: " + vote.Error))
+		default:
+			sb.WriteString(mutedTextStyle.Render("Rankings: "))
+		}
 		for i, agentID := range vote.Rankings {
-			points := m.session.AgentCount - 1 - i
+			points := len(m.session.Scores) - 1 - i
 			if i > 0 {
 				sb.WriteString(" → ")
 			}
-			rankText := fmt.Sprintf("Agent %d (%dpts)", agentID, points)
+			rankText := fmt.Sprintf("%s (%dpts)", m.session.AgentLabel(agentID), points)
 			if m.session.WinnerID != nil && *m.session.WinnerID == agentID {
 				sb.WriteString(winnerStyle.Render(rankText))
 			} else {
@@ -294,6 +468,17 @@ func (m Model) renderVotes() string {
 		}
 		sb.WriteString("\n\n")
 
+		// Rubric scores
+		if len(vote.Scores) > 0 {
+			sb.WriteString(mutedTextStyle.Render("Scores:"))
+			sb.WriteString("\n")
+			for _, agentID := range sortedIDs(vote.Scores) {
+				sb.WriteString(contentStyle.Render(fmt.Sprintf("  %s: %s", m.session.AgentLabel(agentID), m.formatCriteria(vote.Scores[agentID], "%.0f"))))
+				sb.WriteString("\n")
+			}
+			sb.WriteString("\n")
+		}
+
 		// Reasoning
 		if vote.Reasoning != "" {
 			sb.WriteString(mutedTextStyle.Render("Reasoning: "))
@@ -301,6 +486,11 @@ func (m Model) renderVotes() string {
 			sb.WriteString("\n")
 		}
 
+		if m.showThinking && vote.Thinking != "" {
+			sb.WriteString("\n")
+			m.writeThinking(&sb, vote.Thinking)
+		}
+
 		sb.WriteString("\n")
 		sb.WriteString(divider(m.width - 8))
 		sb.WriteString("\n\n")
@@ -309,6 +499,61 @@ func (m Model) renderVotes() string {
 	return sb.String()
 }
 
+// formatCriteria lists scores per criterion in rubric order
+func (m Model) formatCriteria(scores map[string]float64, format string) string {
+	if m.session.Config == nil {
+		return ""
+	}
+	var parts []string
+	for _, criterion := range m.session.Config.Rubric {
+		if score, ok := scores[criterion.Name]; ok {
+			parts = append(parts, fmt.Sprintf("%s "+format, criterion.Name, score))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// renderJudgments renders the pairwise judgments, grouped by voter
+func (m Model) renderJudgments() string {
+	var sb strings.Builder
+
+	sb.WriteString(headerStyle.Render("Pairwise Judgments"))
+	sb.WriteString("\n\n")
+
+	voter := 0
+	for _, j := range m.session.Judgments {
+		if j.VoterID != voter {
+			if voter != 0 {
+				sb.WriteString(divider(m.width - 8))
+				sb.WriteString("\n\n")
+			}
+			voter = j.VoterID
+			sb.WriteString(subHeaderStyle.Render(fmt.Sprintf("%s's Judgments", m.session.AgentLabel(voter))))
+			sb.WriteString("\n\n")
+		}
+
+		sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("A: %s vs B: %s → ", m.session.AgentLabel(j.A), m.session.AgentLabel(j.B))))
+		winner := m.session.AgentLabel(j.WinnerID)
+		if m.session.WinnerID != nil && *m.session.WinnerID == j.WinnerID {
+			sb.WriteString(winnerStyle.Render(winner))
+		} else {
+			sb.WriteString(contentStyle.Render(winner))
+		}
+		sb.WriteString("\n")
+		if j.Reasoning != "" {
+			sb.WriteString(contentStyle.Render(j.Reasoning))
+			sb.WriteString("\n")
+		}
+		if m.showThinking && j.Thinking != "" {
+			sb.WriteString("\n")
+			m.writeThinking(&sb, j.Thinking)
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
 // renderResults renders the results summary
 func (m Model) renderResults() string {
 	var sb strings.Builder
@@ -320,12 +565,25 @@ func (m Model) renderResults() string {
 	sb.WriteString(subHeaderStyle.Render("Scores"))
 	sb.WriteString("\n\n")
 
-	for i := 1; i <= m.session.AgentCount; i++ {
+	for _, st := range m.session.Standings() {
+		i := st.AgentID
 		score := m.session.Scores[i]
 		isWinner := m.session.WinnerID != nil && *m.session.WinnerID == i
 		isTied := m.session.IsTie && contains(m.session.TiedAgents, i)
 
-		line := fmt.Sprintf("Agent %d: %d points", i, score)
+		line := fmt.Sprintf("%s: %d points", m.session.AgentLabel(i), score)
+		if strength, ok := m.session.Strengths[i]; ok {
+			line = fmt.Sprintf("%s: %d pairwise wins, strength %.3f", m.session.AgentLabel(i), score, strength)
+		}
+		if weighted, ok := m.session.WeightedScores[i]; ok {
+			line = fmt.Sprintf("%s: %.1f weighted points (%d unweighted)", m.session.AgentLabel(i), weighted, score)
+		}
+		if answer, ok := m.session.Answers[i]; ok {
+			line = fmt.Sprintf("%s: %s, given by %d of %d solutions", m.session.AgentLabel(i), answerText(answer), score, len(m.session.Solutions))
+		}
+		if m.session.TalliedByCluster() {
+			line += fmt.Sprintf(", cluster %.1f points", st.Score)
+		}
 		if isWinner {
 			line += " ★ WINNER"
 			sb.WriteString(winnerStyle.Render(line))
@@ -339,6 +597,22 @@ func (m Model) renderResults() string {
 	}
 
 	sb.WriteString("\n")
+
+	if clusters := m.renderClusters(); clusters != "" {
+		sb.WriteString(clusters)
+	}
+
+	if len(m.session.RubricTotals) > 0 {
+		sb.WriteString(subHeaderStyle.Render("Rubric"))
+		sb.WriteString("\n\n")
+		for _, i := range sortedIDs(m.session.RubricScores) {
+			line := fmt.Sprintf("%s: %.2f (%s)", m.session.AgentLabel(i), m.session.RubricTotals[i], m.formatCriteria(m.session.RubricScores[i], "%.1f"))
+			sb.WriteString(contentStyle.Render(line))
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
 	sb.WriteString(divider(m.width - 8))
 	sb.WriteString("\n\n")
 
@@ -348,7 +622,7 @@ func (m Model) renderResults() string {
 		sb.WriteString("\n\n")
 		sb.WriteString(mutedTextStyle.Render("No single winner - review solutions to decide."))
 	} else if m.session.WinnerID != nil {
-		sb.WriteString(winnerStyle.Render(fmt.Sprintf("Winner: Agent %d", *m.session.WinnerID)))
+		sb.WriteString(winnerStyle.Render(fmt.Sprintf("Winner: %s", m.session.AgentLabel(*m.session.WinnerID))))
 		sb.WriteString("\n\n")
 
 		// Show winning solution
@@ -374,8 +648,82 @@ func (m Model) renderResults() string {
 	sb.WriteString("\n")
 	sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("Agents: %d", m.session.AgentCount)))
 	sb.WriteString("\n")
+	if m.session.Judges > 0 {
+		judges := fmt.Sprintf("Judges: %d", m.session.Judges)
+		if m.session.Config != nil && m.session.Config.JudgeModel != "" {
+			judges += fmt.Sprintf(" (%s)", m.session.Config.JudgeModel)
+		}
+		sb.WriteString(mutedTextStyle.Render(judges))
+		sb.WriteString("\n")
+	}
 	sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("Rounds: %d", m.session.Rounds)))
 	sb.WriteString("\n")
+	if m.session.Mode != "" && m.session.Mode != types.ModeCouncil {
+		sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("Mode: %s", m.session.Mode)))
+		sb.WriteString("\n")
+	}
+	if m.session.Profile != "" {
+		sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("Profile: %s", m.session.Profile)))
+		sb.WriteString("\n")
+	}
+	for _, img := range m.session.Images {
+		sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("Image: %s (%s, %d bytes, sha256 %s)", img.Path, img.MediaType, img.Size, truncate(img.SHA256, 15))))
+		sb.WriteString("\n")
+	}
+	for _, f := range m.session.ContextFiles {
+		sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("Context: %s (%d bytes, sha256 %s)", f.Path, f.Size, truncate(f.SHA256, 15))))
+		sb.WriteString("\n")
+	}
+	for _, phase := range types.Phases {
+		if params, ok := m.session.Sampling[phase]; ok {
+			sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("Sampling (%s): %s", phase, formatSampling(params))))
+			sb.WriteString("\n")
+		}
+	}
+	if m.session.BatchID != "" {
+		sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("Batch: %s (task %s)", m.session.BatchID, m.session.BatchTaskID)))
+		sb.WriteString("\n")
+	}
+	if m.session.Usage.Total() > 0 {
+		sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("Tokens: %d in, %d out", m.session.Usage.InputTokens, m.session.Usage.OutputTokens)))
+		sb.WriteString("\n")
+	}
+	if end := m.session.DiscussionEnd; end != nil {
+		sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("Discussion ended early: %s after round %d of %d (%s)", end.Reason, end.Round, m.session.Rounds, end.Detail)))
+		sb.WriteString("\n")
+	}
+	if len(m.session.Weights) > 0 {
+		var parts []string
+		for _, id := range sortedIDs(m.session.Weights) {
+			parts = append(parts, fmt.Sprintf("%s %.2f", m.session.AgentLabel(id), m.session.Weights[id]))
+		}
+		source := ""
+		if m.session.Config != nil && m.session.Config.AccuracyWeights {
+			source = " (graded accuracy in past bench runs)"
+		}
+		sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("Vote weights%s: %s", source, strings.Join(parts, ", "))))
+		sb.WriteString("\n")
+	}
+	if m.session.Config != nil && m.session.Config.WeighConfidence {
+		sb.WriteString(mutedTextStyle.Render("Vote weights include each voter's confidence"))
+		sb.WriteString("\n")
+	}
+	if len(m.session.Abstentions) > 0 {
+		sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("Abstained: %s", m.agentLabels(m.session.Abstentions))))
+		sb.WriteString("\n")
+	}
+	if len(m.session.FailedVotes) > 0 {
+		sb.WriteString(warningStyle.Render(fmt.Sprintf("Votes failed: %s", m.agentLabels(m.session.FailedVotes))))
+		sb.WriteString("\n")
+	}
+	if len(m.session.Moderation) > 0 {
+		sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("Moderator: %d round(s) summarised", len(m.session.Moderation))))
+		sb.WriteString("\n")
+	}
+	if stop := m.session.Stop; stop != nil {
+		sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("Stopped early: %s after %s (%s)", stop.Reason, stop.Phase, stop.Detail)))
+		sb.WriteString("\n")
+	}
 	sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("Created: %s", m.session.CreatedAt.Format("2006-01-02 15:04:05"))))
 	sb.WriteString("\n")
 	sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("Completed: %s", m.session.CompletedAt.Format("2006-01-02 15:04:05"))))
@@ -383,12 +731,198 @@ func (m Model) renderResults() string {
 	return sb.String()
 }
 
+// answerText quotes an extracted final answer, or notes that there was none
+func answerText(extracted string) string {
+	if extracted == "" {
+		return "none found"
+	}
+	return fmt.Sprintf("%q", extracted)
+}
+
+// byWeight qualifies a straw poll's agreement when it was measured by vote weight
+func byWeight(poll types.StrawPoll) string {
+	if poll.WeightedScores != nil {
+		return " by vote weight"
+	}
+	return ""
+}
+
+// clusterMates returns the other members of an agent's solution cluster
+func (m Model) clusterMates(id int) []int {
+	for _, cluster := range m.session.Clusters {
+		if !contains(cluster.AgentIDs, id) {
+			continue
+		}
+		var others []int
+		for _, other := range cluster.AgentIDs {
+			if other != id {
+				others = append(others, other)
+			}
+		}
+		return others
+	}
+	return nil
+}
+
+// renderClusters renders the clusters of near-duplicate solutions, with every
+// cluster's points when the vote was tallied by cluster; empty if there are none
+func (m Model) renderClusters() string {
+	tallied := m.session.Config != nil && m.session.Config.Cluster != nil && m.session.Config.Cluster.Tally
+
+	var sb strings.Builder
+	for _, cluster := range m.session.Clusters {
+		if len(cluster.AgentIDs) < 2 && !tallied {
+			continue
+		}
+		line := m.agentLabels(cluster.AgentIDs)
+		if len(cluster.AgentIDs) > 1 {
+			line += fmt.Sprintf(" (similarity %.2f)", cluster.Similarity)
+		}
+		if tallied {
+			line += fmt.Sprintf(": %.1f points", cluster.Points)
+		}
+		sb.WriteString(contentStyle.Render(line))
+		sb.WriteString("\n")
+	}
+	if sb.Len() == 0 {
+		return ""
+	}
+
+	heading := "Clusters"
+	if tallied {
+		heading = "Clusters (decide the vote)"
+	}
+	return subHeaderStyle.Render(heading) + "\n\n" + sb.String() + "\n"
+}
+
+// agentLabels joins the display labels of the given agents, judges or human
+func (m Model) agentLabels(ids []int) string {
+	labels := make([]string, len(ids))
+	for i, id := range ids {
+		labels[i] = m.session.AgentLabel(id)
+	}
+	return strings.Join(labels, ", ")
+}
+
+// renderSynthesis renders the merged answer and the solutions it drew on
+func (m Model) renderSynthesis() string {
+	var sb strings.Builder
+	syn := m.session.Synthesis
+
+	sb.WriteString(headerStyle.Render("Synthesis"))
+	sb.WriteString("\n\n")
+
+	labels := make(map[int]string)
+	for _, st := range m.session.Standings() {
+		labels[st.AgentID] = st.Label
+	}
+	sources := make([]string, len(syn.SourceIDs))
+	for i, id := range syn.SourceIDs {
+		sources[i] = fmt.Sprintf("%s (%s)", m.session.AgentLabel(id), labels[id])
+	}
+	sb.WriteString(agentLabelStyle.Render(m.session.AgentLabel(syn.AgentID)))
+	sb.WriteString(" ")
+	sb.WriteString(mutedTextStyle.Render("merging " + strings.Join(sources, ", ")))
+	if syn.Truncated {
+		sb.WriteString(" ")
+		sb.WriteString(warningStyle.Render("truncated at max_tokens"))
+	}
+	sb.WriteString("\n\n")
+
+	m.writeThinking(&sb, syn.Thinking)
+
+	sb.WriteString(contentStyle.Render(syn.Content))
+
+	return sb.String()
+}
+
+// renderBracket renders a tournament's groups, who advanced, and the final
+func (m Model) renderBracket() string {
+	var sb strings.Builder
+	bracket := m.session.Bracket
+
+	sb.WriteString(headerStyle.Render("Tournament Bracket"))
+	sb.WriteString("\n\n")
+	sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("%d agents in %d groups of about %d", m.session.AgentCount, len(bracket.Groups), bracket.GroupSize)))
+	sb.WriteString("\n\n")
+
+	for _, g := range bracket.Groups {
+		sb.WriteString(subHeaderStyle.Render(fmt.Sprintf("Group %d", g.ID)))
+		sb.WriteString("\n\n")
+
+		for _, id := range g.AgentIDs {
+			line := fmt.Sprintf("%s: %d points", m.session.AgentLabel(id), g.Scores[id])
+			if contains(g.WinnerIDs, id) {
+				sb.WriteString(winnerStyle.Render(line + " → advanced"))
+			} else {
+				sb.WriteString(contentStyle.Render(line))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+
+		for _, vote := range g.Votes {
+			if vote.Status != "" {
+				sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("%s: vote %s", m.session.AgentLabel(vote.VoterID), vote.Status)))
+				sb.WriteString("\n")
+				continue
+			}
+			ranked := make([]string, len(vote.Rankings))
+			for i, id := range vote.Rankings {
+				ranked[i] = fmt.Sprint(id)
+			}
+			sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("%s ranked: %s", m.session.AgentLabel(vote.VoterID), strings.Join(ranked, " → "))))
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+
+		for _, crit := range g.Critiques {
+			sb.WriteString(agentLabelStyle.Render(fmt.Sprintf("%s's Critique (Round %d)", m.session.AgentLabel(crit.AgentID), crit.Round)))
+			sb.WriteString("\n\n")
+			m.writeThinking(&sb, crit.Thinking)
+			sb.WriteString(contentStyle.Render(crit.Content))
+			sb.WriteString("\n\n")
+		}
+
+		sb.WriteString(divider(m.width - 8))
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString(subHeaderStyle.Render("Final"))
+	sb.WriteString("\n\n")
+	for _, id := range bracket.Finalists {
+		line := fmt.Sprintf("%s: %d points", m.session.AgentLabel(id), m.session.Scores[id])
+		if m.session.WinnerID != nil && *m.session.WinnerID == id {
+			sb.WriteString(winnerStyle.Render(line + " ★ WINNER"))
+		} else {
+			sb.WriteString(contentStyle.Render(line))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	sb.WriteString(mutedTextStyle.Render("The final's discussion and votes are on the Discussion and Votes tabs."))
+
+	return sb.String()
+}
+
+// writeThinking renders an agent's extended thinking when thinking is revealed
+func (m Model) writeThinking(sb *strings.Builder, thinking string) {
+	if !m.showThinking || thinking == "" {
+		return
+	}
+	sb.WriteString(mutedTextStyle.Render("Thinking"))
+	sb.WriteString("\n")
+	sb.WriteString(mutedTextStyle.Render(thinking))
+	sb.WriteString("\n\n")
+}
+
 // renderHelp renders the help bar
 func (m Model) renderHelp() string {
 	keys := []string{
-		helpKeyStyle.Render("1-4") + " tabs",
+		helpKeyStyle.Render(fmt.Sprintf("1-%d", len(m.tabs))) + " tabs",
 		helpKeyStyle.Render("←/→") + " switch",
 		helpKeyStyle.Render("↑/↓") + " scroll",
+		helpKeyStyle.Render("t") + " thinking",
 		helpKeyStyle.Render("q") + " quit",
 	}
 	return helpStyle.Render(strings.Join(keys, "  │  "))
@@ -396,6 +930,27 @@ func (m Model) renderHelp() string {
 
 // Helper functions
 
+// formatSampling summarises the non-default sampling parameters for one phase
+func formatSampling(p types.SamplingParams) string {
+	var parts []string
+	if p.Temperature != nil {
+		parts = append(parts, fmt.Sprintf("temperature=%g", *p.Temperature))
+	}
+	if p.TopP != nil {
+		parts = append(parts, fmt.Sprintf("top_p=%g", *p.TopP))
+	}
+	if p.MaxTokens > 0 {
+		parts = append(parts, fmt.Sprintf("max_tokens=%d", p.MaxTokens))
+	}
+	if len(p.StopSequences) > 0 {
+		parts = append(parts, fmt.Sprintf("stop=%q", p.StopSequences))
+	}
+	if p.ThinkingBudget > 0 {
+		parts = append(parts, fmt.Sprintf("thinking=%d", p.ThinkingBudget))
+	}
+	return strings.Join(parts, " ")
+}
+
 func truncate(s string, max int) string {
 	if len(s) <= max {
 		return s
@@ -403,6 +958,16 @@ func truncate(s string, max int) string {
 	return s[:max-3] + "..."
 }
 
+// sortedIDs returns the agent IDs keying a map, in order
+func sortedIDs[V any](byID map[int]V) []int {
+	ids := make([]int, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
 func contains(slice []int, val int) bool {
 	for _, v := range slice {
 		if v == val {
@@ -415,3 +980,11 @@ func contains(slice []int, val int) bool {
 var warningStyle = lipgloss.NewStyle().
 	Bold(true).
 	Foreground(warningColor)
+
+var passStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(secondaryColor)
+
+var failStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(errorColor)
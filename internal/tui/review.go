@@ -0,0 +1,257 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/humzahkiani/council/internal/types"
+)
+
+// reviewStep is one screen of the human review
+type reviewStep int
+
+const (
+	stepRead reviewStep = iota
+	stepCritique
+	stepRank
+)
+
+// ReviewModel lets a human read the anonymised solutions, write a critique and rank them
+type ReviewModel struct {
+	task      string
+	solutions []string // Solution contents, labelled A, B, ... in order
+	step      reviewStep
+	viewport  viewport.Model
+	critique  textarea.Model
+	ranking   textinput.Model
+	rankErr   string
+	result    []int // Indexes into solutions, best first; set once the ranking is submitted
+	skipped   bool
+	width     int
+	height    int
+	ready     bool
+}
+
+// NewReviewModel creates a review of the given solutions, shown without their authors
+func NewReviewModel(task string, solutions []string) ReviewModel {
+	critique := textarea.New()
+	critique.Placeholder = "Strengths, weaknesses and suggested improvements for each solution..."
+	critique.ShowLineNumbers = false
+	critique.CharLimit = 0
+
+	ranking := textinput.New()
+	ranking.Placeholder = "e.g. " + strings.Join(exampleRanking(len(solutions)), " ")
+
+	return ReviewModel{
+		task:      task,
+		solutions: solutions,
+		critique:  critique,
+		ranking:   ranking,
+	}
+}
+
+// exampleRanking returns the labels of n solutions in reverse, as a placeholder ranking
+func exampleRanking(n int) []string {
+	labels := make([]string, n)
+	for i := range labels {
+		labels[i] = types.SolutionLabel(n - 1 - i)
+	}
+	return labels
+}
+
+// Critique returns the human's critique
+func (m ReviewModel) Critique() string {
+	return m.critique.Value()
+}
+
+// Ranking returns the submitted ranking as indexes into the solutions, best first, or nil if the human skipped
+func (m ReviewModel) Ranking() []int {
+	return m.result
+}
+
+// Skipped reports whether the human left without submitting a ranking
+func (m ReviewModel) Skipped() bool {
+	return m.skipped || m.result == nil
+}
+
+// Init initializes the model
+func (m ReviewModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m ReviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			m.skipped = true
+			return m, tea.Quit
+		}
+
+		switch m.step {
+		case stepRead:
+			switch msg.String() {
+			case "q":
+				m.skipped = true
+				return m, tea.Quit
+			case "enter", "tab":
+				m.step = stepCritique
+				return m, m.critique.Focus()
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+			}
+
+		case stepCritique:
+			switch msg.String() {
+			case "esc":
+				m.critique.Blur()
+				m.step = stepRead
+			case "tab":
+				m.critique.Blur()
+				m.step = stepRank
+				return m, m.ranking.Focus()
+			default:
+				m.critique, cmd = m.critique.Update(msg)
+			}
+
+		case stepRank:
+			switch msg.String() {
+			case "esc":
+				m.ranking.Blur()
+				m.step = stepCritique
+				return m, m.critique.Focus()
+			case "enter":
+				ranking, err := parseRanking(m.ranking.Value(), len(m.solutions))
+				if err != nil {
+					m.rankErr = err.Error()
+					return m, nil
+				}
+				m.result = ranking
+				return m, tea.Quit
+			default:
+				m.ranking, cmd = m.ranking.Update(msg)
+			}
+		}
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+		headerHeight := 6 // Title + task + step + padding
+		footerHeight := 3 // Help text
+
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width-4, msg.Height-headerHeight-footerHeight)
+			m.viewport.SetContent(m.renderSolutions())
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width - 4
+			m.viewport.Height = msg.Height - headerHeight - footerHeight
+		}
+		m.critique.SetWidth(msg.Width - 4)
+		m.critique.SetHeight(msg.Height - headerHeight - footerHeight)
+		m.ranking.Width = msg.Width - 8
+	}
+
+	return m, cmd
+}
+
+// parseRanking reads solution labels, best first, separated by spaces, commas
+// or ">". Every solution must be ranked exactly once. With at most 26
+// solutions the labels can also be run together, e.g. "BAC".
+func parseRanking(input string, n int) ([]int, error) {
+	fields := strings.FieldsFunc(strings.ToUpper(input), func(r rune) bool {
+		return r == ' ' || r == ',' || r == '>'
+	})
+	if len(fields) == 1 && n > 1 && n <= 26 && len(fields[0]) == n {
+		fields = strings.Split(fields[0], "")
+	}
+
+	index := make(map[string]int, n)
+	for i := 0; i < n; i++ {
+		index[types.SolutionLabel(i)] = i
+	}
+
+	var ranking []int
+	seen := make(map[int]bool)
+	for _, label := range fields {
+		i, ok := index[label]
+		if !ok {
+			return nil, fmt.Errorf("unknown solution %q", label)
+		}
+		if seen[i] {
+			return nil, fmt.Errorf("solution %s is ranked twice", label)
+		}
+		seen[i] = true
+		ranking = append(ranking, i)
+	}
+	if len(ranking) != n {
+		return nil, fmt.Errorf("rank all %d solutions (got %d)", n, len(ranking))
+	}
+
+	return ranking, nil
+}
+
+// View renders the UI
+func (m ReviewModel) View() string {
+	if !m.ready {
+		return "Loading..."
+	}
+
+	title := titleStyle.Render("Council of Elders — Your Review")
+	task := mutedTextStyle.Render(fmt.Sprintf("Task: %s", truncate(m.task, m.width-10)))
+
+	var step, content, help string
+	switch m.step {
+	case stepRead:
+		step = subHeaderStyle.Render(fmt.Sprintf("Step 1 of 3: Read the %d solutions", len(m.solutions)))
+		content = m.viewport.View()
+		help = "↑/↓: scroll • enter: write critique • q: skip review"
+	case stepCritique:
+		step = subHeaderStyle.Render("Step 2 of 3: Critique the solutions (optional), referring to them by letter")
+		content = m.critique.View()
+		help = "tab: rank solutions • esc: back to solutions • ctrl+c: skip review"
+	case stepRank:
+		step = subHeaderStyle.Render("Step 3 of 3: Rank every solution, best first")
+		content = m.ranking.View()
+		if m.rankErr != "" {
+			content += "\n\n" + warningStyle.Render(m.rankErr)
+		}
+		help = "enter: submit • esc: back to critique • ctrl+c: skip review"
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		task,
+		step,
+		"",
+		content,
+		helpStyle.Render(help),
+	)
+}
+
+// renderSolutions renders the anonymised solutions
+func (m ReviewModel) renderSolutions() string {
+	var sb strings.Builder
+
+	sb.WriteString(contentStyle.Render(m.task))
+	sb.WriteString("\n\n")
+	for i, content := range m.solutions {
+		sb.WriteString(divider(m.width - 8))
+		sb.WriteString("\n\n")
+		sb.WriteString(agentLabelStyle.Render(fmt.Sprintf("Solution %s", types.SolutionLabel(i))))
+		sb.WriteString("\n\n")
+		sb.WriteString(contentStyle.Render(content))
+		sb.WriteString("\n\n")
+	}
+
+	return sb.String()
+}
@@ -1,12 +1,119 @@
 package types
 
-import "time"
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Phase identifies a stage of the council process
+type Phase string
+
+const (
+	PhaseGenerate   Phase = "generate"
+	PhaseDiscuss    Phase = "discuss"
+	PhaseModerate   Phase = "moderate" // Optional, after each discussion round
+	PhaseVote       Phase = "vote"
+	PhaseSynthesize Phase = "synthesize" // Optional, after the tally
+)
+
+// Phases lists every phase in execution order
+var Phases = []Phase{PhaseGenerate, PhaseDiscuss, PhaseModerate, PhaseVote, PhaseSynthesize}
+
+// SamplingParams controls model sampling for one phase; zero values use the API defaults
+type SamplingParams struct {
+	Temperature    *float64 `json:"temperature,omitempty" yaml:"temperature"`
+	TopP           *float64 `json:"top_p,omitempty" yaml:"top_p"`
+	MaxTokens      int      `json:"max_tokens,omitempty" yaml:"max_tokens"` // 0 uses the client default
+	StopSequences  []string `json:"stop_sequences,omitempty" yaml:"stop_sequences"`
+	ThinkingBudget int      `json:"thinking_budget,omitempty" yaml:"thinking_budget"` // Extended thinking token budget; 0 disables thinking
+}
+
+// Mode selects how the council is structured
+type Mode string
+
+const (
+	ModeCouncil         Mode = "council"          // Every agent discusses and ranks every solution
+	ModeTournament      Mode = "tournament"       // Groups run mini-councils; group winners advance to a final
+	ModeSelfConsistency Mode = "self-consistency" // Agents only generate; the most common final answer wins
+)
+
+// Modes lists every council mode
+var Modes = []Mode{ModeCouncil, ModeTournament, ModeSelfConsistency}
+
+// MinGroupSize is the smallest tournament group in which voting is meaningful,
+// since agents can't vote for themselves
+const MinGroupSize = 3
+
+// VotingMethod selects how votes are collected and tallied
+type VotingMethod string
+
+const (
+	VotingBorda    VotingMethod = "borda"    // Ranked-choice with Borda count points
+	VotingPairwise VotingMethod = "pairwise" // Head-to-head judgments scored with Bradley-Terry
+)
+
+// VotingMethods lists every supported voting method
+var VotingMethods = []VotingMethod{VotingBorda, VotingPairwise}
+
+// Usage counts the tokens used across API requests
+type Usage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// Total returns input plus output tokens
+func (u Usage) Total() int {
+	return u.InputTokens + u.OutputTokens
+}
+
+// StopReason explains why a run ended before completing every phase
+type StopReason string
+
+const (
+	StopBudget    StopReason = "budget"    // Token budget exhausted
+	StopModerator StopReason = "moderator" // Moderator judged further discussion rounds unnecessary
+	StopConsensus StopReason = "consensus" // A straw poll reached the consensus threshold
+)
+
+// Stop records an early end to a run
+type Stop struct {
+	Reason StopReason `json:"reason"`
+	Phase  Phase      `json:"phase"`           // Last phase that completed
+	Round  int        `json:"round,omitempty"` // Last discussion round that completed, if any
+	Detail string     `json:"detail,omitempty"`
+	At     time.Time  `json:"at"`
+}
 
 // Solution represents an agent's proposed solution to the task
 type Solution struct {
 	AgentID   int       `json:"agent_id"`
 	Content   string    `json:"content"`
+	Truncated bool      `json:"truncated,omitempty"` // Still hit max_tokens after continuation
+	Thinking  string    `json:"thinking,omitempty"`  // Extended thinking, never shown to other agents
 	CreatedAt time.Time `json:"created_at"`
+
+	Verification *Verification `json:"verification,omitempty"` // Test results for the solution's code blocks
+}
+
+// Verification records running a solution's code blocks against the test command
+type Verification struct {
+	Passed     bool     `json:"passed"`
+	ExitCode   int      `json:"exit_code"`
+	TimedOut   bool     `json:"timed_out,omitempty"`
+	Output     string   `json:"output"`          // Combined stdout and stderr, keeping the end if long
+	Files      []string `json:"files,omitempty"` // Files written from the code blocks
+	Error      string   `json:"error,omitempty"` // Why the command couldn't run, e.g. no code blocks
+	DurationMS int64    `json:"duration_ms"`
+}
+
+// VerifyConfig configures running solutions' code against a test command
+type VerifyConfig struct {
+	Command       string        `json:"command"`
+	Timeout       time.Duration `json:"timeout"`
+	MemoryLimitMB int           `json:"memory_limit_mb,omitempty"` // Virtual memory limit via ulimit -v; 0 is unlimited
+	Files         []string      `json:"files,omitempty"`           // Test files and fixtures copied next to the code
+	Show          bool          `json:"show"`                      // Show results to agents during discussion and voting
 }
 
 // Critique represents an agent's critique of all solutions
@@ -14,41 +121,375 @@ type Critique struct {
 	AgentID   int       `json:"agent_id"`
 	Round     int       `json:"round"`
 	Content   string    `json:"content"`
+	Truncated bool      `json:"truncated,omitempty"` // Still hit max_tokens after continuation
+	Thinking  string    `json:"thinking,omitempty"`  // Extended thinking, never shown to other agents
 	CreatedAt time.Time `json:"created_at"`
 }
 
 // Vote represents an agent's ranked-choice vote
 type Vote struct {
+	VoterID    int                        `json:"voter_id"`
+	Rankings   []int                      `json:"rankings"`             // Ordered list of AgentIDs, best first (excludes self)
+	Reasoning  string                     `json:"reasoning"`            // Agent's explanation for their vote
+	Thinking   string                     `json:"thinking,omitempty"`   // Extended thinking behind the ranking
+	Scores     map[int]map[string]float64 `json:"scores,omitempty"`     // With a rubric: AgentID -> criterion -> score from 1 to 10
+	Weight     float64                    `json:"weight,omitempty"`     // The voter's weight, multiplying the vote's Borda points; 0 counts as 1
+	Confidence float64                    `json:"confidence,omitempty"` // Voter's confidence in its ranking, in (0, 1]; 0 if not given
+	Status     VoteStatus                 `json:"status,omitempty"`     // Empty for a cast vote
+	Error      string                     `json:"error,omitempty"`      // Why the vote failed, with status failed
+}
+
+// VoteStatus marks a vote that ranks nothing, and why
+type VoteStatus string
+
+const (
+	VoteAbstained VoteStatus = "abstained" // The voter chose not to judge, e.g. lacking the domain knowledge
+	VoteFailed    VoteStatus = "failed"    // No valid vote could be parsed, even after a retry
+)
+
+// Cast reports whether the vote ranks solutions, rather than abstaining or failing
+func (v Vote) Cast() bool {
+	return v.Status == "" && len(v.Rankings) > 0
+}
+
+// Criterion is one weighted rubric criterion that voters score solutions against
+type Criterion struct {
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"` // Weights across the rubric sum to 1
+}
+
+// RubricMin and RubricMax bound the score a voter gives a solution on one criterion
+const (
+	RubricMin = 1
+	RubricMax = 10
+)
+
+// ModeratorMode selects when a moderator runs the discussion
+type ModeratorMode string
+
+const (
+	ModeratorAuto ModeratorMode = "auto" // On for councils of ModeratorMinAgents or more
+	ModeratorOn   ModeratorMode = "on"
+	ModeratorOff  ModeratorMode = "off"
+)
+
+// ModeratorModes lists every moderator mode
+var ModeratorModes = []ModeratorMode{ModeratorAuto, ModeratorOn, ModeratorOff}
+
+const (
+	// ModeratorMinAgents is the council size at which auto mode adds a moderator
+	ModeratorMinAgents = 6
+	// ModeratorID identifies the moderator, which has no solution of its own
+	ModeratorID = 0
+)
+
+// ModeratorNote is the moderator's summary of one discussion round
+type ModeratorNote struct {
+	Round         int                 `json:"round"`
+	Summary       string              `json:"summary"`
+	Disagreements []string            `json:"disagreements,omitempty"`
+	Questions     []ModeratorQuestion `json:"questions,omitempty"` // Put to agents in the next round
+	Continue      bool                `json:"continue"`            // Another round is needed; always false after the last round
+	Reason        string              `json:"reason,omitempty"`    // Why another round is or isn't needed
+	Thinking      string              `json:"thinking,omitempty"`
+	CreatedAt     time.Time           `json:"created_at"`
+}
+
+// ModeratorQuestion is a follow-up question for one agent
+type ModeratorQuestion struct {
+	AgentID  int    `json:"agent_id"`
+	Question string `json:"question"`
+}
+
+// StrawPoll is an interim vote taken after a discussion round to check for consensus
+type StrawPoll struct {
+	Round          int             `json:"round"`
+	Votes          []Vote          `json:"votes"`
+	Scores         map[int]int     `json:"scores"`
+	WeightedScores map[int]float64 `json:"weighted_scores,omitempty"` // Set when the votes are weighted; the leader is decided on these
+	LeaderID       *int            `json:"leader_id"`                 // nil when the poll is tied
+	Agreement      float64         `json:"agreement"`                 // Share of the other agents, by vote weight, that ranked the leader first
+	Consensus      bool            `json:"consensus"`                 // Agreement reached the threshold
+}
+
+// Group is one mini-council in a tournament's group stage
+type Group struct {
+	ID        int         `json:"id"`
+	AgentIDs  []int       `json:"agent_ids"`
+	Critiques []Critique  `json:"critiques"`
+	Votes     []Vote      `json:"votes"`
+	Scores    map[int]int `json:"scores"`
+	WinnerIDs []int       `json:"winner_ids"` // Agents that advanced; more than one on a tie
+}
+
+// Bracket records a tournament: the group stage and who advanced to the final.
+// The final itself is stored in the session's critiques, votes and scores.
+type Bracket struct {
+	GroupSize int     `json:"group_size"`
+	Groups    []Group `json:"groups"`
+	Finalists []int   `json:"finalists"`
+}
+
+// HumanID identifies a human participant's critique and vote
+const HumanID = -1
+
+// HumanConfig adds a human participant who critiques and ranks the anonymised solutions
+type HumanConfig struct {
+	Weight float64 `json:"weight"` // Multiplies the human vote's Borda points
+}
+
+// SolutionLabel returns the anonymised label for the ith solution shown to a human: A, B, ..., Z, AA, AB, ...
+func SolutionLabel(i int) string {
+	label := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		label = string(rune('A'+(i-1)%26)) + label
+	}
+	return label
+}
+
+// ClusterConfig groups near-duplicate solutions after generation
+type ClusterConfig struct {
+	Threshold float64 `json:"threshold"`       // Text similarity, between 0 and 1, at which two solutions join a cluster
+	Tally     bool    `json:"tally,omitempty"` // Decide the vote over clusters, so near-duplicates don't split points
+}
+
+// Cluster is a group of solutions whose text is near-identical
+type Cluster struct {
+	AgentIDs   []int   `json:"agent_ids"`            // Members, lowest ID first
+	Similarity float64 `json:"similarity,omitempty"` // Mean pairwise similarity of the members; 0 for a lone solution
+	Points     float64 `json:"points,omitempty"`     // Borda points for the cluster as a whole, when tallied by cluster
+}
+
+// ConsensusConfig configures ending discussion early once a straw poll shows consensus
+type ConsensusConfig struct {
+	Threshold float64 `json:"threshold"` // Agreement needed, between 0 and 1
+}
+
+// PairwiseJudgment is one voter's verdict on a pair of solutions
+type PairwiseJudgment struct {
 	VoterID   int    `json:"voter_id"`
-	Rankings  []int  `json:"rankings"`  // Ordered list of AgentIDs, best first (excludes self)
-	Reasoning string `json:"reasoning"` // Agent's explanation for their vote
+	A         int    `json:"a"` // AgentID of the solution shown first
+	B         int    `json:"b"` // AgentID of the solution shown second
+	WinnerID  int    `json:"winner_id"`
+	Reasoning string `json:"reasoning"`
+	Thinking  string `json:"thinking,omitempty"`
+}
+
+// Synthesis is a final answer merging the best ideas from the top-ranked solutions
+type Synthesis struct {
+	AgentID   int       `json:"agent_id"`   // The synthesizer
+	SourceIDs []int     `json:"source_ids"` // AgentIDs of the solutions it was given, best first
+	Content   string    `json:"content"`
+	Truncated bool      `json:"truncated,omitempty"` // Still hit max_tokens after continuation
+	Thinking  string    `json:"thinking,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SynthesisConfig configures the optional synthesis step
+type SynthesisConfig struct {
+	TopK        int `json:"top_k"`       // How many top-ranked solutions the synthesizer receives
+	Synthesizer int `json:"synthesizer"` // AgentID of the synthesizer; 0 uses the winner (or top-ranked agent on a tie)
+}
+
+// Persona is a named role that adds its own instructions to an agent's prompts
+type Persona struct {
+	Name         string `json:"name" yaml:"name"`
+	Instructions string `json:"instructions,omitempty" yaml:"instructions"` // Added to every phase
+	Generate     string `json:"generate,omitempty" yaml:"generate"`         // Added to the generation prompt
+	Critique     string `json:"critique,omitempty" yaml:"critique"`         // Added to the discussion prompt
+	Vote         string `json:"vote,omitempty" yaml:"vote"`                 // Added to the voting prompt
+}
+
+// ContextFile records a file attached to the task as context
+type ContextFile struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// ImageAttachment records an image attached to the task
+type ImageAttachment struct {
+	Path      string `json:"path"`
+	MediaType string `json:"media_type"`
+	Size      int64  `json:"size"`
+	SHA256    string `json:"sha256"`
 }
 
 // Session represents a complete council session
 type Session struct {
-	ID          string         `json:"id"`
-	Task        string         `json:"task"`
-	AgentCount  int            `json:"agent_count"`
-	Rounds      int            `json:"rounds"`
-	Model       string         `json:"model"`
-	Solutions   []Solution     `json:"solutions"`
-	Critiques   []Critique     `json:"critiques"`
-	Votes       []Vote         `json:"votes"`
-	Scores      map[int]int    `json:"scores"`
-	WinnerID    *int           `json:"winner_id"`
-	IsTie       bool           `json:"is_tie"`
-	TiedAgents  []int          `json:"tied_agents"`
-	CreatedAt   time.Time      `json:"created_at"`
-	CompletedAt time.Time      `json:"completed_at"`
-}
-
-// Config holds CLI configuration
+	ID             string                     `json:"id"`
+	Task           string                     `json:"task"`
+	AgentCount     int                        `json:"agent_count"`
+	Judges         int                        `json:"judges,omitempty"` // Separate judges, numbered after the agents
+	Rounds         int                        `json:"rounds"`
+	Model          string                     `json:"model"`
+	Voting         VotingMethod               `json:"voting,omitempty"`
+	Mode           Mode                       `json:"mode,omitempty"`
+	Bracket        *Bracket                   `json:"bracket,omitempty"`  // Set in tournament mode
+	Profile        string                     `json:"profile,omitempty"`  // Config profile the run used
+	BatchID        string                     `json:"batch_id,omitempty"` // Set when run as part of council batch
+	BatchTaskID    string                     `json:"batch_task_id,omitempty"`
+	Config         *Config                    `json:"config,omitempty"`      // Effective configuration after merging files and flags
+	Personas       map[int]string             `json:"personas,omitempty"`    // AgentID -> persona name
+	PromptHash     string                     `json:"prompt_hash,omitempty"` // SHA-256 of the resolved prompt templates
+	Sampling       map[Phase]SamplingParams   `json:"sampling,omitempty"`
+	ContextFiles   []ContextFile              `json:"context_files,omitempty"`
+	Images         []ImageAttachment          `json:"images,omitempty"`
+	Solutions      []Solution                 `json:"solutions"`
+	Critiques      []Critique                 `json:"critiques"`
+	Votes          []Vote                     `json:"votes"`
+	Scores         map[int]int                `json:"scores"`                    // Borda points, pairwise wins with --voting pairwise, or solutions sharing the answer in self-consistency mode
+	WeightedScores map[int]float64            `json:"weighted_scores,omitempty"` // Borda points multiplied by vote weights, and confidence with weigh_confidence; set when a vote is weighted
+	Weights        map[int]float64            `json:"weights,omitempty"`         // Vote weight applied per voter ID, from --weights
+	RubricScores   map[int]map[string]float64 `json:"rubric_scores,omitempty"`   // AgentID -> criterion -> mean score across voters
+	RubricTotals   map[int]float64            `json:"rubric_totals,omitempty"`   // AgentID -> weighted sum of the mean criterion scores
+	HumanOrder     []int                      `json:"human_order,omitempty"`     // AgentIDs of the solutions shown to the human as A, B, ...
+	Clusters       []Cluster                  `json:"clusters,omitempty"`        // With --cluster, every solution's cluster, including lone ones
+	Answers        map[int]string             `json:"answers,omitempty"`         // AgentID -> final answer extracted from the solution, in self-consistency mode
+	Grades         map[int]bool               `json:"grades,omitempty"`          // AgentID -> whether council bench graded the solution correct
+	Abstentions    []int                      `json:"abstentions,omitempty"`     // Voter IDs that abstained from the final vote
+	FailedVotes    []int                      `json:"failed_votes,omitempty"`    // Voter IDs whose final vote failed
+	Judgments      []PairwiseJudgment         `json:"judgments,omitempty"`       // With --voting pairwise
+	Strengths      map[int]float64            `json:"strengths,omitempty"`       // Bradley-Terry strengths from the judgments, summing to 1
+	WinnerID       *int                       `json:"winner_id"`
+	IsTie          bool                       `json:"is_tie"`
+	TiedAgents     []int                      `json:"tied_agents"`
+	Synthesis      *Synthesis                 `json:"synthesis,omitempty"`
+	Moderation     []ModeratorNote            `json:"moderation,omitempty"`     // One note per moderated discussion round
+	StrawPolls     []StrawPoll                `json:"straw_polls,omitempty"`    // With --early-stop, one per round before the last
+	DiscussionEnd  *Stop                      `json:"discussion_end,omitempty"` // Set when discussion ended before the configured rounds
+	Usage          Usage                      `json:"usage"`
+	Stop           *Stop                      `json:"stop,omitempty"` // Set when the run ended early
+	CreatedAt      time.Time                  `json:"created_at"`
+	CompletedAt    time.Time                  `json:"completed_at"`
+}
+
+// AgentLabel returns the display name for an agent, using its persona when one was assigned
+func (s *Session) AgentLabel(id int) string {
+	if id == ModeratorID {
+		return "Moderator"
+	}
+	if id == HumanID {
+		return "Human"
+	}
+	if s.Judges > 0 && id > s.AgentCount {
+		return fmt.Sprintf("Judge %d", id-s.AgentCount)
+	}
+	if name := s.Personas[id]; name != "" {
+		return fmt.Sprintf("%s (Agent %d)", name, id)
+	}
+	return fmt.Sprintf("Agent %d", id)
+}
+
+// TalliedByCluster reports whether the vote was decided over clusters of near-duplicate solutions
+func (s *Session) TalliedByCluster() bool {
+	return s.Config != nil && s.Config.Cluster != nil && s.Config.Cluster.Tally && len(s.Clusters) > 0
+}
+
+// Standing is an agent's place in the result
+type Standing struct {
+	AgentID int
+	Score   float64 // The score that decided the result
+	Label   string  // The score with its unit, e.g. "8.4 weighted points"
+}
+
+// Standings ranks the agents in Scores, best first, by the score that decided
+// the result: the cluster's points when tallied by cluster, Bradley-Terry
+// strength with pairwise voting, weighted points when the vote was weighted,
+// else points. Members of one cluster are ordered by their own score, so the
+// winner comes first; remaining ties go to the lowest ID.
+func (s *Session) Standings() []Standing {
+	clusterPoints := make(map[int]float64)
+	byCluster := s.TalliedByCluster()
+	if byCluster {
+		for _, cluster := range s.Clusters {
+			for _, id := range cluster.AgentIDs {
+				clusterPoints[id] = cluster.Points
+			}
+		}
+	}
+
+	standings := make([]Standing, 0, len(s.Scores))
+	for id, points := range s.Scores {
+		st := Standing{AgentID: id, Score: float64(points), Label: fmt.Sprintf("%d points", points)}
+		if _, ok := s.Answers[id]; ok {
+			st.Label = fmt.Sprintf("answer given by %d of %d solutions", points, len(s.Solutions))
+		} else if strength, ok := s.Strengths[id]; ok {
+			st.Score, st.Label = strength, fmt.Sprintf("strength %.3f", strength)
+		} else if weighted, ok := s.WeightedScores[id]; ok {
+			st.Score, st.Label = weighted, fmt.Sprintf("%.1f weighted points", weighted)
+		}
+		standings = append(standings, st)
+	}
+
+	sort.Slice(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if byCluster && clusterPoints[a.AgentID] != clusterPoints[b.AgentID] {
+			return clusterPoints[a.AgentID] > clusterPoints[b.AgentID]
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.AgentID < b.AgentID
+	})
+
+	if byCluster {
+		for i, st := range standings {
+			points := clusterPoints[st.AgentID]
+			standings[i].Score = points
+			standings[i].Label = fmt.Sprintf("%.1f cluster points (own: %s)", points, st.Label)
+		}
+	}
+	return standings
+}
+
+// Config holds the effective run configuration
 type Config struct {
-	AgentCount int
-	Rounds     int
-	Save       bool
-	OutputPath string
-	Verbose    bool
-	Model      string
-	Task       string
+	Profile         string                   `json:"profile,omitempty"` // Config file profile the options came from
+	AgentCount      int                      `json:"agent_count"`
+	Judges          int                      `json:"judges,omitempty"`      // Separate agents that only critique and vote; 0 means the solvers do
+	JudgeModel      string                   `json:"judge_model,omitempty"` // Model for the judges; empty uses Model
+	Rounds          int                      `json:"rounds"`
+	Save            bool                     `json:"save"`
+	OutputPath      string                   `json:"output_path,omitempty"`
+	Verbose         bool                     `json:"verbose"`
+	Model           string                   `json:"model"`
+	Voting          VotingMethod             `json:"voting"`
+	Rubric          []Criterion              `json:"rubric,omitempty"`           // Criteria voters score each solution on, alongside their ranking
+	Weights         map[int]float64          `json:"weights,omitempty"`          // Vote weight per voter ID; unlisted voters weigh 1
+	AccuracyWeights bool                     `json:"accuracy_weights,omitempty"` // Weight voters by how often their first choice was graded correct in past bench runs
+	WeighConfidence bool                     `json:"weigh_confidence,omitempty"` // Multiply each vote's weight by the voter's stated confidence
+	Moderator       ModeratorMode            `json:"moderator,omitempty"`
+	Mode            Mode                     `json:"mode,omitempty"`
+	GroupSize       int                      `json:"group_size,omitempty"`  // Target agents per tournament group
+	Personas        []Persona                `json:"personas,omitempty"`    // Assigned to agents round-robin; empty means no personas
+	PromptsDir      string                   `json:"prompts_dir,omitempty"` // Directory of prompt template overrides; empty uses ~/.council/prompts/
+	Sampling        map[Phase]SamplingParams `json:"sampling,omitempty"`
+	TokenBudget     int                      `json:"token_budget,omitempty"` // Stop after the phase that reaches this many tokens; 0 is unlimited
+	Task            string                   `json:"-"`                      // Stored on the session itself
+
+	ImagePaths           []string `json:"image_paths,omitempty"`   // PNG/JPEG files sent to every agent as image content
+	ContextPaths         []string `json:"context_paths,omitempty"` // Files, directories or globs attached as context
+	ContextMaxFileBytes  int64    `json:"context_max_file_bytes"`  // Per-file size limit; 0 uses the default
+	ContextMaxTotalBytes int64    `json:"context_max_total_bytes"` // Total size limit; 0 uses the default
+
+	Verify    *VerifyConfig    `json:"verify,omitempty"`    // Run solutions' code blocks against a test command; nil disables
+	Synthesis *SynthesisConfig `json:"synthesis,omitempty"` // Merge the top solutions after the tally; nil disables
+	Consensus *ConsensusConfig `json:"consensus,omitempty"` // Straw poll after each round, ending discussion on consensus; nil disables
+	Cluster   *ClusterConfig   `json:"cluster,omitempty"`   // Group near-duplicate solutions after generation; nil disables
+	Human     *HumanConfig     `json:"human,omitempty"`     // A human critiques and votes alongside the agents; nil disables
+}
+
+// Moderated reports whether the run has a moderator, resolving auto mode by council size
+func (c *Config) Moderated() bool {
+	switch c.Moderator {
+	case ModeratorOn:
+		return true
+	case ModeratorAuto:
+		// Judges take over the discussion, so auto mode only moderates the solvers;
+		// self-consistency mode has no discussion to moderate
+		return c.Judges == 0 && c.Mode != ModeSelfConsistency && c.AgentCount >= ModeratorMinAgents
+	default:
+		return false
+	}
 }
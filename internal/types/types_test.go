@@ -0,0 +1,86 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStandings(t *testing.T) {
+	tests := []struct {
+		name       string
+		session    Session
+		wantOrder  []int
+		wantLabels []string
+	}{
+		{
+			name:       "points, ties to the lowest ID",
+			session:    Session{Scores: map[int]int{1: 2, 2: 4, 3: 2}},
+			wantOrder:  []int{2, 1, 3},
+			wantLabels: []string{"4 points", "2 points", "2 points"},
+		},
+		{
+			name: "weighted points decide over raw points",
+			session: Session{
+				Scores:         map[int]int{1: 4, 2: 3},
+				WeightedScores: map[int]float64{1: 2.5, 2: 4.5},
+			},
+			wantOrder:  []int{2, 1},
+			wantLabels: []string{"4.5 weighted points", "2.5 weighted points"},
+		},
+		{
+			name: "strength decides with pairwise voting",
+			session: Session{
+				Scores:    map[int]int{1: 3, 2: 3, 3: 0},
+				Strengths: map[int]float64{1: 0.3, 2: 0.6, 3: 0.1},
+			},
+			wantOrder:  []int{2, 1, 3},
+			wantLabels: []string{"strength 0.600", "strength 0.300", "strength 0.100"},
+		},
+		{
+			name: "cluster points decide, then each member's own",
+			session: Session{
+				Config:         &Config{Cluster: &ClusterConfig{Threshold: 0.8, Tally: true}},
+				Scores:         map[int]int{1: 5, 2: 1, 3: 2, 4: 3},
+				WeightedScores: map[int]float64{1: 5, 2: 1, 3: 2, 4: 3},
+				Clusters: []Cluster{
+					{AgentIDs: []int{1}, Points: 4},
+					{AgentIDs: []int{2, 3, 4}, Points: 6},
+				},
+			},
+			wantOrder: []int{4, 3, 2, 1},
+			wantLabels: []string{
+				"6.0 cluster points (own: 3.0 weighted points)",
+				"6.0 cluster points (own: 2.0 weighted points)",
+				"6.0 cluster points (own: 1.0 weighted points)",
+				"4.0 cluster points (own: 5.0 weighted points)",
+			},
+		},
+		{
+			name: "clusters ignored without tallying by them",
+			session: Session{
+				Config:   &Config{Cluster: &ClusterConfig{Threshold: 0.8}},
+				Scores:   map[int]int{1: 5, 2: 1},
+				Clusters: []Cluster{{AgentIDs: []int{1}}, {AgentIDs: []int{2}}},
+			},
+			wantOrder:  []int{1, 2},
+			wantLabels: []string{"5 points", "1 points"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var order []int
+			var labels []string
+			for _, st := range tt.session.Standings() {
+				order = append(order, st.AgentID)
+				labels = append(labels, st.Label)
+			}
+			if !reflect.DeepEqual(order, tt.wantOrder) {
+				t.Errorf("order = %v, want %v", order, tt.wantOrder)
+			}
+			if !reflect.DeepEqual(labels, tt.wantLabels) {
+				t.Errorf("labels = %q, want %q", labels, tt.wantLabels)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package verify
+
+import "os/exec"
+
+// killGroupOnCancel leaves the default cancellation, which kills only the shell
+func killGroupOnCancel(cmd *exec.Cmd) {}
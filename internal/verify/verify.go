@@ -0,0 +1,243 @@
+// Package verify runs a solution's code blocks against a test command in a
+// throwaway directory, with time and memory limits.
+package verify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/humzahkiani/council/internal/codeblock"
+	"github.com/humzahkiani/council/internal/types"
+)
+
+const (
+	// DefaultTimeout limits how long the test command may run
+	DefaultTimeout = 60 * time.Second
+	// maxOutput is how much command output is kept; the end is kept since it usually holds the summary
+	maxOutput = 8 * 1024
+)
+
+// Run writes the solution's code blocks to a new temp directory alongside the
+// configured test files, then runs the test command there. Blocks that name a
+// file are written under that name; the rest become solution.<ext>,
+// solution_2.<ext> and so on. A solution may not write over a test file. The
+// command runs with a minimal environment and passes when it exits 0.
+func Run(ctx context.Context, cfg *types.VerifyConfig, content string) *types.Verification {
+	result := &types.Verification{ExitCode: -1}
+
+	blocks := codeblock.Extract(content)
+	if len(blocks) == 0 {
+		result.Error = "no fenced code blocks found"
+		return result
+	}
+
+	dir, err := os.MkdirTemp("", "council-verify-")
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to create temp directory: %v", err)
+		return result
+	}
+	defer os.RemoveAll(dir)
+
+	result.Files, err = writeBlocks(dir, blocks)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if name, ok := overwritesTests(result.Files, cfg.Files); ok {
+		result.Error = fmt.Sprintf("solution writes %s, which is a test file", name)
+		return result
+	}
+
+	// Tests are copied last, so nothing the solution wrote can replace them
+	for _, path := range cfg.Files {
+		if err := copyPath(path, dir); err != nil {
+			result.Error = fmt.Sprintf("failed to copy test file: %v", err)
+			return result
+		}
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	script := cfg.Command
+	if cfg.MemoryLimitMB > 0 {
+		script = fmt.Sprintf("ulimit -v %d && %s", cfg.MemoryLimitMB*1024, script)
+	}
+
+	cmd := exec.CommandContext(runCtx, "sh", "-c", script)
+	cmd.Dir = dir
+	cmd.Env = environ(dir, result.Files)
+	killGroupOnCancel(cmd)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	cmd.WaitDelay = time.Second // Don't hang on background processes holding the output open
+
+	start := time.Now()
+	err = cmd.Run()
+	result.DurationMS = time.Since(start).Milliseconds()
+	result.Output = tail(output.String(), maxOutput)
+
+	var exitErr *exec.ExitError
+	switch {
+	case runCtx.Err() == context.DeadlineExceeded:
+		result.TimedOut = true
+		result.Error = fmt.Sprintf("timed out after %s", timeout)
+	case ctx.Err() != nil:
+		result.Error = ctx.Err().Error()
+	case err == nil:
+		result.Passed = true
+		result.ExitCode = 0
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	default:
+		result.Error = fmt.Sprintf("failed to run test command: %v", err)
+	}
+
+	return result
+}
+
+// Environ returns the environment for a command checking model-written code
+// in dir. The code gets nothing from the parent environment beyond PATH and
+// LANG, and HOME is dir; in particular, no API keys.
+func Environ(dir string) []string {
+	env := []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + dir,
+	}
+	if lang, ok := os.LookupEnv("LANG"); ok {
+		env = append(env, "LANG="+lang)
+	}
+	return env
+}
+
+// environ returns the test command's environment, which also names the solution's files
+func environ(dir string, files []string) []string {
+	return append(Environ(dir),
+		"COUNCIL_SOLUTION_DIR="+dir,
+		"COUNCIL_SOLUTION_FILES="+strings.Join(files, " "),
+	)
+}
+
+// overwritesTests returns the first written file that is, or is inside, one of
+// the test files or directories, which are copied in under their base names
+func overwritesTests(files, testPaths []string) (string, bool) {
+	for _, name := range files {
+		for _, test := range testPaths {
+			base := filepath.Base(filepath.Clean(test))
+			if name == base || strings.HasPrefix(name, base+"/") {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// writeBlocks writes code blocks into dir, returning the relative paths written.
+// Unnamed blocks, and blocks repeating a name, get the next unused solution name.
+func writeBlocks(dir string, blocks []codeblock.Block) ([]string, error) {
+	var files []string
+	written := make(map[string]bool)
+	counts := make(map[string]int) // Extension -> unnamed blocks so far
+
+	for _, block := range blocks {
+		name := block.Filename
+		for name == "" || written[name] {
+			ext := block.Extension()
+			counts[ext]++
+			name = "solution" + ext
+			if counts[ext] > 1 {
+				name = fmt.Sprintf("solution_%d%s", counts[ext], ext)
+			}
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", name, err)
+		}
+		if err := os.WriteFile(path, []byte(block.Code), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+
+		written[name] = true
+		files = append(files, name)
+	}
+
+	return files, nil
+}
+
+// copyPath copies a file or directory into dir, keeping its base name
+func copyPath(src, dir string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return copyFile(src, filepath.Join(dir, filepath.Base(src)))
+	}
+
+	root := filepath.Join(dir, filepath.Base(filepath.Clean(src)))
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(root, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		return copyFile(path, target)
+	})
+}
+
+// copyFile copies one regular file, keeping its permissions
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// tail keeps the last n bytes of s
+func tail(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return "...(output truncated)\n" + s[len(s)-n:]
+}
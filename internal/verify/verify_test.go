@@ -0,0 +1,129 @@
+package verify
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/humzahkiani/council/internal/codeblock"
+	"github.com/humzahkiani/council/internal/types"
+)
+
+func TestWriteBlocksNames(t *testing.T) {
+	py := func(name, code string) codeblock.Block {
+		return codeblock.Block{Lang: "python", Filename: name, Code: code}
+	}
+
+	tests := []struct {
+		name   string
+		blocks []codeblock.Block
+		want   []string
+	}{
+		{"unnamed", []codeblock.Block{py("", "a"), py("", "b")}, []string{"solution.py", "solution_2.py"}},
+		{"explicit then unnamed", []codeblock.Block{py("solution.py", "a"), py("", "b")}, []string{"solution.py", "solution_2.py"}},
+		{"explicit second name taken", []codeblock.Block{py("solution_2.py", "a"), py("", "b"), py("", "c")}, []string{"solution_2.py", "solution.py", "solution_3.py"}},
+		{"repeated name", []codeblock.Block{py("main.py", "a"), py("main.py", "b")}, []string{"main.py", "solution.py"}},
+		{"extensions counted apart", []codeblock.Block{py("", "a"), {Lang: "go", Code: "b"}}, []string{"solution.py", "solution.go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			files, err := writeBlocks(dir, tt.blocks)
+			if err != nil {
+				t.Fatalf("writeBlocks: %v", err)
+			}
+			if !reflect.DeepEqual(files, tt.want) {
+				t.Fatalf("files = %v, want %v", files, tt.want)
+			}
+			for i, name := range files {
+				data, err := os.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(data) != tt.blocks[i].Code {
+					t.Errorf("%s = %q, want %q", name, data, tt.blocks[i].Code)
+				}
+			}
+		})
+	}
+}
+
+func TestRunRejectsOverwritingTests(t *testing.T) {
+	testDir := t.TempDir()
+	testFile := filepath.Join(testDir, "check.sh")
+	if err := os.WriteFile(testFile, []byte("exit 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	suite := filepath.Join(testDir, "tests")
+	if err := os.MkdirAll(suite, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		files    []string
+		solution string
+	}{
+		{"test file", []string{testFile}, "```sh check.sh\nexit 0\n```"},
+		{"file in test directory", []string{suite}, "```sh tests/run.sh\nexit 0\n```"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &types.VerifyConfig{Command: "sh check.sh", Files: tt.files}
+			result := Run(context.Background(), cfg, tt.solution)
+			if result.Passed {
+				t.Fatal("solution replaced the tests and passed")
+			}
+			if !strings.Contains(result.Error, "test file") {
+				t.Errorf("Error = %q, want it to name the test file", result.Error)
+			}
+		})
+	}
+}
+
+func TestRunKeepsTests(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "check.sh")
+	if err := os.WriteFile(testFile, []byte("grep -q ok solution.txt\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &types.VerifyConfig{Command: "sh check.sh", Files: []string{testFile}}
+
+	result := Run(context.Background(), cfg, "```text\nok\n```")
+	if !result.Passed {
+		t.Fatalf("Passed = false (error %q, output %q)", result.Error, result.Output)
+	}
+}
+
+func TestRunEnvironment(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "secret")
+	cfg := &types.VerifyConfig{Command: `test -z "$ANTHROPIC_API_KEY" && test "$HOME" = "$COUNCIL_SOLUTION_DIR"`}
+
+	result := Run(context.Background(), cfg, "```sh\necho hi\n```")
+	if !result.Passed {
+		t.Fatalf("test command saw the parent environment (error %q, output %q)", result.Error, result.Output)
+	}
+}
+
+func TestRunTimeoutKillsChildren(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "survived")
+	cfg := &types.VerifyConfig{
+		Command: "sh -c 'sleep 2; touch " + marker + "' & wait",
+		Timeout: 200 * time.Millisecond,
+	}
+
+	result := Run(context.Background(), cfg, "```sh\necho hi\n```")
+	if !result.TimedOut {
+		t.Fatalf("TimedOut = false (error %q)", result.Error)
+	}
+
+	time.Sleep(2500 * time.Millisecond)
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("the sleep child kept running after the timeout")
+	}
+}